@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"rpc_test/methods"
+)
+
+var (
+	hotRatio    float64
+	hotFraction float64
+	shardIndex  int
+	shardCount  int
+
+	// batchPoolSize is the --batch-pool number of batches precomputed per run, 0 builds each batch per request
+	batchPoolSize int
+
+	// cacheHitThreshold is the --warn-on-cache-hit p50 below which deterministic runs are suspected of hitting a cache
+	cacheHitThreshold time.Duration
+
+	// uniquePerWorker gives each worker a disjoint range of the accounts when there are enough of them
+	uniquePerWorker bool
+
+	// accountOffset skips this many accounts before --limit takes its window
+	accountOffset int
+
+	// accountList holds the --accounts addresses, merged into accounts by loadAccounts
+	accountList commaAccounts
+)
+
+// commaAccounts is a flag of comma separated account addresses, each checked as it is parsed
+type commaAccounts []string
+
+func (c *commaAccounts) String() string {
+	return strings.Join(*c, ",")
+}
+
+// Set appends the addresses of one --accounts value, the flag can be repeated
+func (c *commaAccounts) Set(value string) error {
+	for _, address := range strings.Split(value, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		if err := methods.ValidateAddress(address); err != nil {
+			return err
+		}
+		*c = append(*c, address)
+	}
+	return nil
+}
+
+func (c *commaAccounts) Type() string {
+	return "addresses"
+}
+
+// hotSetStats counts how many account picks landed in the hot set
+type hotSetStats struct {
+	hits  atomic.Int64
+	picks atomic.Int64
+}
+
+// accountPicker chooses the accounts each request of a worker targets
+type accountPicker struct {
+	accounts []string
+	workerID int
+	rng      *rand.Rand
+	hotCount int // the first hotCount accounts form the hot set, 0 when disabled
+	stats    *hotSetStats
+	rotating bool // walk the worker's own range request by request instead of reading fixed accounts
+	next     int  // offset of the next rotating pick
+}
+
+// validateHotSet checks the --hot-ratio/--hot-fraction pair
+func validateHotSet() error {
+	if hotRatio < 0 || hotRatio > 1 {
+		return fmt.Errorf("--hot-ratio must be between 0 and 1, got %v", hotRatio)
+	}
+	if hotFraction < 0 || hotFraction >= 1 {
+		return fmt.Errorf("--hot-fraction must be between 0 (disabled) and 1, got %v", hotFraction)
+	}
+	return nil
+}
+
+// hotSetSize returns how many accounts form the hot set, 0 when the hot set is disabled
+func hotSetSize(totalAccounts int) int {
+	if hotFraction <= 0 || totalAccounts < 2 {
+		return 0
+	}
+
+	size := int(math.Ceil(hotFraction * float64(totalAccounts)))
+	if size >= totalAccounts {
+		size = totalAccounts - 1
+	}
+	return size
+}
+
+// disjointWorkers returns how many workers get their own account range under --unique-per-worker,
+// 0 when workers share the list: the flag is off, the hot set is on or there are fewer accounts than workers
+func disjointWorkers(totalAccounts int) int {
+	if !uniquePerWorker || hotSetSize(totalAccounts) > 0 || totalAccounts < concurrency {
+		return 0
+	}
+	return concurrency
+}
+
+// workerRange returns the worker's disjoint range of the accounts, the whole list when workers share it
+func workerRange(accounts []string, workerID int) []string {
+	workers := disjointWorkers(len(accounts))
+	if workers == 0 {
+		return accounts
+	}
+
+	// Workers added by --sla-latency beyond --concurrency reuse the ranges from the start
+	size := len(accounts) / workers
+	start := workerID % workers * size
+	return accounts[start : start+size]
+}
+
+// accountSharingWarning explains why workers read the same accounts at once, "" when they don't collide
+func accountSharingWarning(totalAccounts int) string {
+	if uniquePerWorker && hotSetSize(totalAccounts) > 0 {
+		return "--unique-per-worker has no effect with --hot-fraction, whose weighted picks share the hot set across workers by design"
+	}
+	if totalAccounts >= concurrency {
+		return ""
+	}
+
+	warning := fmt.Sprintf("--concurrency %d exceeds the %d accounts, so several workers read the same accounts at once and share the endpoint's cache", concurrency, totalAccounts)
+	if uniquePerWorker {
+		warning += ". --unique-per-worker falls back to rotation"
+	}
+	return warning + ". Seed more accounts for per-account-independent numbers"
+}
+
+// newAccountPicker creates a picker with its own RNG derived from the run seed
+func newAccountPicker(accounts []string, workerID int, runSeed int64, stats *hotSetStats) *accountPicker {
+	return &accountPicker{
+		accounts: workerRange(accounts, workerID),
+		workerID: workerID,
+		rng:      rand.New(rand.NewSource(runSeed + int64(workerID))),
+		hotCount: hotSetSize(len(accounts)),
+		stats:    stats,
+		rotating: disjointWorkers(len(accounts)) > 0,
+	}
+}
+
+// offset returns where the next n unweighted picks start: the worker's fixed position, or the next
+// accounts of its own range under --unique-per-worker
+func (p *accountPicker) offset(n int) int {
+	if !p.rotating {
+		return p.workerID
+	}
+	start := p.next
+	p.next += n
+	return start
+}
+
+// single returns the account for a single-account request
+func (p *accountPicker) single() string {
+	if p.hotCount == 0 {
+		return p.accounts[p.offset(1)%len(p.accounts)]
+	}
+	return p.weighted()
+}
+
+// batch returns n accounts for a getMultipleAccounts request
+func (p *accountPicker) batch(n int) []string {
+	batchAccounts := make([]string, 0, n)
+	start := 0
+	if p.hotCount == 0 {
+		start = p.offset(n)
+	}
+	for i := 0; i < n; i++ {
+		if p.hotCount == 0 {
+			batchAccounts = append(batchAccounts, p.accounts[(start+i)%len(p.accounts)])
+		} else {
+			batchAccounts = append(batchAccounts, p.weighted())
+		}
+	}
+	return batchAccounts
+}
+
+// weighted sends hotRatio of the picks to the hot set and the rest to the long tail
+func (p *accountPicker) weighted() string {
+	p.stats.picks.Add(1)
+
+	if p.rng.Float64() < hotRatio {
+		p.stats.hits.Add(1)
+		return p.accounts[p.rng.Intn(p.hotCount)]
+	}
+	return p.accounts[p.hotCount+p.rng.Intn(len(p.accounts)-p.hotCount)]
+}
+
+// randomBatchSize returns the 5-14 accounts of a batched request, capped at the accounts available
+func randomBatchSize(rng *rand.Rand, available int) int {
+	return min(rng.Intn(10)+5, available)
+}
+
+// precomputedBatch is one batch of a batchPool with the hot set picks that built it
+type precomputedBatch struct {
+	accounts []string
+	hits     int64
+	picks    int64
+}
+
+// batchPool holds batches built before the run, so workers take one without RNG or allocation in the measured loop
+type batchPool struct {
+	batches []precomputedBatch
+	stats   *hotSetStats
+	workers int // batch i was built from the range of worker i%workers under --unique-per-worker, 0 when shared
+}
+
+// newBatchPool precomputes --batch-pool batches of 5-14 accounts, nil when the pool is disabled
+func newBatchPool(accounts []string, stats *hotSetStats) *batchPool {
+	if batchPoolSize <= 0 || len(accounts) == 0 {
+		return nil
+	}
+
+	rng := rand.New(rand.NewSource(runSeed))
+	workers := disjointWorkers(len(accounts))
+	size := max(batchPoolSize, workers) // at least one batch per disjoint range
+	pool := &batchPool{batches: make([]precomputedBatch, 0, size), stats: stats, workers: workers}
+	for i := 0; i < size; i++ {
+		// Each batch starts at a different account, so rotation covers the list as it does across workers
+		var counts hotSetStats
+		builder := newAccountPicker(accounts, i, runSeed+int64(i), &counts)
+		builder.next = i
+		batch := builder.batch(randomBatchSize(rng, len(builder.accounts)))
+		pool.batches = append(pool.batches, precomputedBatch{accounts: batch, hits: counts.hits.Load(), picks: counts.picks.Load()})
+	}
+	return pool
+}
+
+// take returns the batch for a worker's nth request, each worker walking the pool from its own offset
+func (p *batchPool) take(workerID int, n int) []string {
+	index := (workerID + n) % len(p.batches)
+	if p.workers > 0 {
+		// Only the batches built from the worker's own range
+		index = workerID%p.workers + p.workers*(n%(len(p.batches)/p.workers))
+	}
+	batch := p.batches[index]
+	if batch.picks > 0 {
+		p.stats.picks.Add(batch.picks)
+		p.stats.hits.Add(batch.hits)
+	}
+	return batch.accounts
+}
+
+// hotSetSummary describes the distribution actually achieved, or "" when the hot set is disabled
+func hotSetSummary(result TestResult, totalAccounts int) string {
+	if result.AccountPicks == 0 {
+		return ""
+	}
+
+	hitRate := float64(result.HotSetHits) / float64(result.AccountPicks) * 100
+	return fmt.Sprintf("%.1f%% of account reads hit the hot set (%d of %d accounts)",
+		hitRate, hotSetSize(totalAccounts), totalAccounts)
+}
+
+// cacheHitWarning returns a warning when a method's p50 is implausibly low for deterministic account access,
+// a sign the endpoint served the same few accounts from a hot cache, or "" when the result looks realistic
+func cacheHitWarning(result TestResult) string {
+	if cacheHitThreshold <= 0 || parameterlessMethods[result.MethodName] || result.SuccessCount == 0 {
+		return ""
+	}
+
+	// Weighted picks and --unique-per-worker ranges spread reads over the account list, so only fixed
+	// per-worker access is suspect; the fallback with too few accounts was already warned about up front
+	if result.AccountPicks > 0 || uniquePerWorker || result.P50Latency >= cacheHitThreshold {
+		return ""
+	}
+
+	logEvent("cache_hit_suspected", "method", result.MethodName, "p50_ms", durationMs(result.P50Latency), "threshold_ms", durationMs(cacheHitThreshold))
+	return fmt.Sprintf("p50 of %s is below %s with every worker reading fixed accounts, responses are likely served from a hot cache. Spread the reads with --hot-fraction or seed a larger account set for worst-case numbers",
+		formatLatency(result.P50Latency), formatLatency(cacheHitThreshold))
+}
+
+// accountWindow returns the limit accounts starting at offset, or every account from offset on when limit is 0.
+// Without an offset a limit above the number of accounts keeps them all, with one the window must fit.
+func accountWindow(list []string, offset, limit int) ([]string, error) {
+	if offset < 0 {
+		return nil, fmt.Errorf("account offset must not be negative, got %d", offset)
+	}
+	if offset == 0 {
+		if limit > 0 && limit < len(list) {
+			return list[:limit], nil
+		}
+		return list, nil
+	}
+
+	if offset >= len(list) {
+		return nil, fmt.Errorf("account offset %d is past the %d accounts", offset, len(list))
+	}
+	end := len(list)
+	if limit > 0 {
+		if offset+limit > len(list) {
+			return nil, fmt.Errorf("accounts [%d:%d] run past the %d accounts, lower the offset or --limit", offset, offset+limit, len(list))
+		}
+		end = offset + limit
+	}
+	return list[offset:end], nil
+}
+
+// validateShard checks the --shard-index/--shard-count pair
+func validateShard() error {
+	if shardCount < 1 {
+		return fmt.Errorf("--shard-count must be at least 1, got %d", shardCount)
+	}
+	if shardIndex < 0 || shardIndex >= shardCount {
+		return fmt.Errorf("--shard-index must be between 0 and %d for --shard-count %d, got %d", shardCount-1, shardCount, shardIndex)
+	}
+	return nil
+}
+
+// shardAccounts keeps every shardCount-th account starting at shardIndex so split runs use disjoint accounts
+func shardAccounts(list []string) []string {
+	if shardCount <= 1 {
+		return list
+	}
+
+	shard := make([]string, 0, len(list)/shardCount+1)
+	for i := shardIndex; i < len(list); i += shardCount {
+		shard = append(shard, list[i])
+	}
+
+	fmt.Fprintf(output, "Using shard %d/%d: %d of %d accounts\n", shardIndex, shardCount, len(shard), len(list))
+	return shard
+}
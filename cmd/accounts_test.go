@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+)
+
+// BenchmarkBatchPick compares taking a precomputed batch from the --batch-pool with building one per request
+// (--batch-pool 0), uniformly and with a hot set
+func BenchmarkBatchPick(b *testing.B) {
+	accounts := make([]string, 10000)
+	for i := range accounts {
+		accounts[i] = fmt.Sprintf("account%05d", i)
+	}
+
+	for _, hot := range []float64{0, 0.1} {
+		b.Run(fmt.Sprintf("hot=%g", hot), func(b *testing.B) {
+			originalHot, originalPool := hotFraction, batchPoolSize
+			b.Cleanup(func() { hotFraction, batchPoolSize = originalHot, originalPool })
+			hotFraction, batchPoolSize = hot, 1024
+
+			var stats hotSetStats
+			b.Run("pool", func(b *testing.B) {
+				pool := newBatchPool(accounts, &stats)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_ = pool.take(0, i)
+				}
+			})
+			b.Run("per-request", func(b *testing.B) {
+				picker := newAccountPicker(accounts, 0, runSeed, &stats)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_ = picker.batch(randomBatchSize(picker.rng, len(accounts)))
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// slaLatency is the --sla-latency p95 target in milliseconds, 0 keeps concurrency fixed
+var slaLatency int
+
+const (
+	// adaptiveInterval is how often the controller re-evaluates the p95 and resizes the pool
+	adaptiveInterval = time.Second
+
+	// adaptiveWindow is the span of recent requests the p95 is measured over
+	adaptiveWindow = 3 * time.Second
+
+	// adaptiveMinSamples keeps the controller from reacting to a handful of requests
+	adaptiveMinSamples = 20
+
+	// maxAdaptiveWorkers caps how far the controller grows the pool
+	maxAdaptiveWorkers = 1024
+)
+
+type timedLatency struct {
+	at      time.Time
+	latency time.Duration
+}
+
+// latencyWindow keeps the latencies of requests completed in the last span
+type latencyWindow struct {
+	mu      sync.Mutex
+	span    time.Duration
+	samples []timedLatency
+}
+
+// add records a request that completed at the given time
+func (w *latencyWindow) add(at time.Time, latency time.Duration) {
+	w.mu.Lock()
+	w.samples = append(w.samples, timedLatency{at: at, latency: latency})
+	w.mu.Unlock()
+}
+
+// p95 drops samples older than the span and returns the p95 of the rest with the sample count
+func (w *latencyWindow) p95(now time.Time) (time.Duration, int) {
+	w.mu.Lock()
+	cutoff := now.Add(-w.span)
+	kept := 0
+	for kept < len(w.samples) && w.samples[kept].at.Before(cutoff) {
+		kept++
+	}
+	w.samples = w.samples[kept:]
+
+	latencies := make([]time.Duration, len(w.samples))
+	for i, sample := range w.samples {
+		latencies[i] = sample.latency
+	}
+	w.mu.Unlock()
+
+	return percentile(latencies, 95), len(latencies)
+}
+
+// adaptiveStep is one controller decision, kept to find the steady state
+type adaptiveStep struct {
+	workers  int
+	requests int64
+}
+
+// adaptivePool grows and shrinks a worker pool to hold the p95 at the --sla-latency target
+type adaptivePool struct {
+	target  time.Duration
+	window  latencyWindow
+	start   func(workerID int, quit <-chan struct{})
+	quits   []chan struct{}
+	nextID  int
+	steps   []adaptiveStep
+	counted func() int64
+	done    chan struct{} // closed when run returns
+
+	mu      sync.Mutex
+	current int
+}
+
+// newAdaptivePool returns a pool that starts workers with start and reads the total request count from counted
+func newAdaptivePool(target time.Duration, start func(workerID int, quit <-chan struct{}), counted func() int64) *adaptivePool {
+	return &adaptivePool{
+		target:  target,
+		window:  latencyWindow{span: adaptiveWindow},
+		start:   start,
+		counted: counted,
+		done:    make(chan struct{}),
+	}
+}
+
+// record adds a completed request to the sliding window, nil-safe so fixed-concurrency runs can call it
+func (p *adaptivePool) record(at time.Time, latency time.Duration) {
+	if p == nil {
+		return
+	}
+	p.window.add(at, latency)
+}
+
+// workers returns the current pool size
+func (p *adaptivePool) workers() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.current
+}
+
+// resize starts or stops workers until n are running
+func (p *adaptivePool) resize(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.quits) < n {
+		quit := make(chan struct{})
+		p.quits = append(p.quits, quit)
+		p.start(p.nextID, quit)
+		p.nextID++
+	}
+	for len(p.quits) > n {
+		last := len(p.quits) - 1
+		close(p.quits[last])
+		p.quits = p.quits[:last]
+	}
+	p.current = n
+}
+
+// run adjusts the pool every adaptiveInterval until stop is closed: additive increase while the p95
+// is under the target, multiplicative decrease when it is over
+func (p *adaptivePool) run(initial int, stop <-chan struct{}) {
+	defer close(p.done)
+	p.resize(initial)
+
+	ticker := time.NewTicker(adaptiveInterval)
+	defer ticker.Stop()
+
+	lastCount := p.counted()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			count := p.counted()
+			workers := p.workers()
+			p.steps = append(p.steps, adaptiveStep{workers: workers, requests: count - lastCount})
+			lastCount = count
+
+			p95, samples := p.window.p95(now)
+			if samples < adaptiveMinSamples {
+				continue
+			}
+
+			next := workers
+			switch {
+			case p95 > p.target:
+				next = workers * 3 / 4
+			case p95 < p.target*9/10:
+				next = workers + max(1, workers/10)
+			}
+			next = min(max(next, 1), maxAdaptiveWorkers)
+
+			if next != workers {
+				p.resize(next)
+				logEvent("concurrency_adjusted", "workers", next, "p95_ms", durationMs(p95), "target_ms", slaLatency)
+			}
+		}
+	}
+}
+
+// steadyState averages the pool size and request rate over the last third of the run, once run has returned
+func (p *adaptivePool) steadyState() (workers float64, rps float64) {
+	if len(p.steps) == 0 {
+		return float64(p.workers()), 0
+	}
+
+	tail := p.steps[len(p.steps)-max(1, len(p.steps)/3):]
+	var totalWorkers, totalRequests int64
+	for _, step := range tail {
+		totalWorkers += int64(step.workers)
+		totalRequests += step.requests
+	}
+	return float64(totalWorkers) / float64(len(tail)), float64(totalRequests) / (float64(len(tail)) * adaptiveInterval.Seconds())
+}
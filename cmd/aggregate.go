@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	aggregateMethod string
+	aggregateMetric string
+)
+
+// aggregateMetrics maps the --metric names to the MethodResult fields they read
+var aggregateMetrics = map[string]func(MethodResult) float64{
+	"requests_per_sec": func(r MethodResult) float64 { return r.RequestsPerSec },
+	"success_rate":     func(r MethodResult) float64 { return r.SuccessRate },
+	"min_latency_ms":   func(r MethodResult) float64 { return r.MinLatencyMs },
+	"avg_latency_ms":   func(r MethodResult) float64 { return r.AvgLatencyMs },
+	"p95_latency_ms":   func(r MethodResult) float64 { return r.P95LatencyMs },
+	"max_latency_ms":   func(r MethodResult) float64 { return r.MaxLatencyMs },
+}
+
+// sparkBars are the sparkline levels from lowest to highest
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// aggregateCmd represents the aggregate command
+var aggregateCmd = &cobra.Command{
+	Use:   "aggregate <dir|file.jsonl>",
+	Short: "Show how a metric evolved across a directory of saved --output result files",
+	Long: `Load every results file saved with --output in a directory and show how one metric 
+of one method evolved over time.
+
+Files are ordered by the timestamp stored inside them, not by file name, and files that 
+don't contain the method are skipped. Files written with --output-append (*.jsonl) 
+contribute one point per run, and a single such file can be passed instead of a directory. The output is a time-ordered table followed by a 
+sparkline of the metric.
+
+Metrics:
+  requests_per_sec, success_rate, min_latency_ms, avg_latency_ms, p95_latency_ms, max_latency_ms
+
+Examples:
+  # p95 latency trend of getAccountInfo
+  rpc_test aggregate ./results
+
+  # RPS trend of getMultipleAccounts
+  rpc_test aggregate ./results --method getMultipleAccounts --metric requests_per_sec
+
+  # Trend of runs appended to one file with --output-append
+  rpc_test aggregate ./history.jsonl`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		value, ok := aggregateMetrics[aggregateMetric]
+		if !ok {
+			log.Fatalf("Unknown --metric %q", aggregateMetric)
+		}
+
+		files, err := loadResultsDir(args[0])
+		if err != nil {
+			log.Fatalf("Failed to load results: %v", err)
+		}
+
+		var points []float64
+		fmt.Fprintf(output, "📈 %s %s across %d result files in %s\n\n", aggregateMethod, aggregateMetric, len(files), args[0])
+		fmt.Fprintf(output, "%-20s %-12s %s\n", "Timestamp", "Value", "Command")
+		for _, file := range files {
+			for _, result := range file.Results {
+				if result.Method != aggregateMethod {
+					continue
+				}
+				points = append(points, value(result))
+				fmt.Fprintf(output, "%-20s %-12.2f %s\n", file.Timestamp.Format("2006-01-02 15:04:05"), value(result), file.Command)
+			}
+		}
+
+		if len(points) == 0 {
+			fmt.Fprintf(output, "\n⚠️  No results for %s found\n", aggregateMethod)
+			return
+		}
+		fmt.Fprintf(output, "\nTrend: %s\n", sparkline(points))
+	},
+}
+
+// loadResultsDir loads every *.json results file and every run in *.jsonl files in dir, oldest first.
+// A path to a single --output-append file loads the runs in it.
+func loadResultsDir(dir string) ([]*ResultsFile, error) {
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		files, err := loadResultsLines(dir)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(files, func(i, j int) bool { return files[i].Timestamp.Before(files[j].Timestamp) })
+		return files, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %v", err)
+	}
+
+	var files []*ResultsFile
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		switch {
+		case strings.HasSuffix(entry.Name(), ".json"):
+			file, err := loadResults(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				fmt.Fprintf(output, "⚠️  Skipping %s: %v\n", entry.Name(), err)
+				continue
+			}
+			files = append(files, file)
+		case strings.HasSuffix(entry.Name(), ".jsonl"):
+			runs, err := loadResultsLines(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				fmt.Fprintf(output, "⚠️  Skipping %s: %v\n", entry.Name(), err)
+				continue
+			}
+			files = append(files, runs...)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Timestamp.Before(files[j].Timestamp) })
+	return files, nil
+}
+
+// sparkline renders values as a row of block characters scaled between their min and max
+func sparkline(values []float64) string {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+
+	var line strings.Builder
+	for _, v := range values {
+		level := 0
+		if high > low {
+			level = int((v - low) / (high - low) * float64(len(sparkBars)-1))
+		}
+		line.WriteRune(sparkBars[level])
+	}
+	return line.String()
+}
+
+func init() {
+	RootCmd.AddCommand(aggregateCmd)
+
+	aggregateCmd.Flags().StringVar(&aggregateMethod, "method", "getAccountInfo", "Method whose results to aggregate")
+	aggregateCmd.Flags().StringVar(&aggregateMetric, "metric", "p95_latency_ms", "Metric to show: requests_per_sec, success_rate, min/avg/p95/max_latency_ms")
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"rpc_test/methods"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// batchingBatchSize is the accounts per getMultipleAccounts request of the batched strategy
+	batchingBatchSize int
+
+	// batchingRounds is how many times each strategy fetches the whole account set
+	batchingRounds int
+)
+
+// maxMultipleAccounts is the most accounts an RPC accepts in one getMultipleAccounts request
+const maxMultipleAccounts = 100
+
+// fetchStats is the cost of fetching the account set with one strategy
+type fetchStats struct {
+	Duration time.Duration
+	Requests int64
+	Failures int64
+	Accounts int64
+}
+
+// accountsPerSec is the effective fetch rate of the strategy
+func (s fetchStats) accountsPerSec() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.Accounts) / s.Duration.Seconds()
+}
+
+// perAccount is the wall-clock time the strategy spent per account fetched
+func (s fetchStats) perAccount() time.Duration {
+	if s.Accounts == 0 {
+		return 0
+	}
+	return s.Duration / time.Duration(s.Accounts)
+}
+
+// batchingCmd represents the batching command
+var batchingCmd = &cobra.Command{
+	Use:   "batching",
+	Short: "Compare fetching accounts one by one with getAccountInfo against batched getMultipleAccounts",
+	Long: `Fetch the same account set twice, once with one getAccountInfo per account and once with
+getMultipleAccounts batches of --batch-size, and report the total time, effective per-account
+latency and the speedup of batching.
+
+Both strategies use --concurrency workers and a fresh client, and fetch every account
+--rounds times, so the comparison answers "should I batch?" for this endpoint and account set.
+
+Examples:
+  # Compare with batches of 100 accounts
+  rpc_test batching --account-file ./accounts.txt --concurrency 10
+
+  # Compare smaller batches over three passes
+  rpc_test batching --account-file ./accounts.txt --batch-size 20 --rounds 3`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if batchingBatchSize < 1 || batchingBatchSize > maxMultipleAccounts {
+			log.Fatalf("--batch-size must be between 1 and %d, got %d", maxMultipleAccounts, batchingBatchSize)
+		}
+		if batchingRounds < 1 {
+			log.Fatalf("--rounds must be at least 1, got %d", batchingRounds)
+		}
+
+		resolveProtocol()
+		resolveCompression()
+		resolveProxy()
+		if err := waitUntilReady(rpcURL); err != nil {
+			log.Fatal(err)
+		}
+		if err := resolveSlot(rpcURL); err != nil {
+			log.Fatal(err)
+		}
+		loadAccounts()
+
+		fmt.Fprintf(output, "Fetching %d accounts %d times per strategy with %d concurrent requests\n", len(accounts), batchingRounds, concurrency)
+		fmt.Fprintf(output, "RPC URL: %s\n", rpcURL)
+
+		fmt.Fprintln(output, "\n🔄 Fetching one by one with getAccountInfo...")
+		single := fetchAccountSet("getAccountInfo", 1)
+		fmt.Fprintf(output, "\n🔄 Fetching in batches of %d with getMultipleAccounts...\n", batchingBatchSize)
+		batched := fetchAccountSet("getMultipleAccounts", batchingBatchSize)
+
+		printBatchingComparison(single, batched)
+	},
+}
+
+// fetchAccountSet fetches every account --rounds times with methodName, batchSize accounts per request
+func fetchAccountSet(methodName string, batchSize int) fetchStats {
+	// A fresh client per strategy so the second doesn't reuse the first one's warm connections
+	rpcTest := methods.NewRPCTestWithOptions(rpcURL, apiKey, clientOptions())
+	rpcTest.SetCallOptions(callOptions())
+
+	jobs := make(chan []string)
+	var stats fetchStats
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range jobs {
+				_, err := methods.Dispatch(context.Background(), methodName, rpcTest, batch...)
+
+				mutex.Lock()
+				stats.Requests++
+				if err != nil {
+					stats.Failures++
+				} else {
+					stats.Accounts += int64(len(batch))
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	start := time.Now()
+	for round := 0; round < batchingRounds; round++ {
+		for i := 0; i < len(accounts); i += batchSize {
+			jobs <- accounts[i:min(i+batchSize, len(accounts))]
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	stats.Duration = time.Since(start)
+
+	fmt.Fprintf(output, "   %d requests in %s, %d failed\n", stats.Requests, stats.Duration.Round(time.Millisecond), stats.Failures)
+	logEvent("batching_strategy_finished",
+		"method", methodName,
+		"batch_size", batchSize,
+		"duration_s", stats.Duration.Seconds(),
+		"requests", stats.Requests,
+		"failures", stats.Failures,
+		"accounts_per_sec", stats.accountsPerSec(),
+	)
+	return stats
+}
+
+// printBatchingComparison prints both strategies side by side with the speedup of batching
+func printBatchingComparison(single, batched fetchStats) {
+	fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "📦 BATCHING COMPARISON")
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(output, "%-22s %-16s %-16s\n", "", "getAccountInfo", fmt.Sprintf("batches of %d", batchingBatchSize))
+	fmt.Fprintf(output, "%-22s %-16s %-16s\n", "Total time", single.Duration.Round(time.Millisecond), batched.Duration.Round(time.Millisecond))
+	fmt.Fprintf(output, "%-22s %-16d %-16d\n", "Requests", single.Requests, batched.Requests)
+	fmt.Fprintf(output, "%-22s %-16d %-16d\n", "Failed requests", single.Failures, batched.Failures)
+	fmt.Fprintf(output, "%-22s %-16.2f %-16.2f\n", "Accounts/second", single.accountsPerSec(), batched.accountsPerSec())
+	fmt.Fprintf(output, "%-22s %-16s %-16s\n", "Per account", formatLatency(single.perAccount()), formatLatency(batched.perAccount()))
+
+	if single.accountsPerSec() == 0 || batched.accountsPerSec() == 0 {
+		fmt.Fprintln(output, "\n⚠️  A strategy fetched no accounts, no speedup to report")
+		return
+	}
+	speedup := batched.accountsPerSec() / single.accountsPerSec()
+	if speedup >= 1 {
+		fmt.Fprintf(output, "\n🚀 Batching is %.1fx faster per account\n", speedup)
+	} else {
+		fmt.Fprintf(output, "\n🐌 Batching is %.1fx slower per account\n", 1/speedup)
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(batchingCmd)
+
+	batchingCmd.Flags().IntVar(&batchingBatchSize, "batch-size", maxMultipleAccounts, "Accounts per getMultipleAccounts request, 1 to 100")
+	batchingCmd.Flags().IntVar(&batchingRounds, "rounds", 1, "How many times each strategy fetches the whole account set")
+}
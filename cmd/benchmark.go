@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"rpc_test/methods"
+
+	"github.com/spf13/cobra"
+)
+
+// benchmarkURLs are the target RPC endpoints benchmark compares
+var benchmarkURLs []string
+
+// benchmarkConcurrency is the concurrency each of benchmarkURLs is tested with, set by parseBenchmarkTargets
+var benchmarkConcurrency []int
+
+// BenchmarkFile is the JSON document benchmark writes to --output
+type BenchmarkFile struct {
+	RunMetadata
+	Providers []BenchmarkProvider `json:"providers"`
+}
+
+// BenchmarkProvider holds the method results of one target URL
+type BenchmarkProvider struct {
+	URL         string         `json:"url"`
+	Concurrency int            `json:"concurrency"`
+	Results     []MethodResult `json:"results"`
+}
+
+// benchmarkCmd represents the benchmark command
+var benchmarkCmd = &cobra.Command{
+	Use:   "benchmark",
+	Short: "Run the method suite against several RPC providers and compare them",
+	Long: `Run the runall method suite against each --url in turn, using the same account set
+for every provider, and print a provider × method matrix of RPS and p95 latency with the
+best provider per method marked.
+
+Features:
+• Same Workload: Every provider is tested with the accounts from --account-file
+• Fresh Clients: Each provider gets its own client, so no connections are shared
+• Winner per Method: The highest RPS and lowest p95 of each method are marked
+• JSON/CSV Output: --output saves the matrix as CSV when it ends in .csv, JSON otherwise
+
+Examples:
+  # Compare three providers
+  rpc_test benchmark --account-file accounts.txt --url https://provider-a.com --url https://provider-b.com --url https://provider-c.com
+
+  # Give a weaker provider fewer workers than a stronger one
+  rpc_test benchmark --account-file accounts.txt --url https://provider-a.com=20 --url https://provider-b.com=5
+
+  # Save the comparison as CSV
+  rpc_test benchmark --account-file accounts.txt --url https://provider-a.com --url https://provider-b.com --output benchmark.csv`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(benchmarkURLs) < 2 {
+			log.Fatalf("benchmark needs at least two --url targets to compare")
+		}
+		parseBenchmarkTargets()
+		if resultsAppend {
+			log.Fatalf("--output-append is not supported by benchmark")
+		}
+		for i, target := range benchmarkURLs {
+			workers, seconds := suiteLoad(benchmarkConcurrency[i], duration, len(runallMethods))
+			confirmLoad(target, workers, seconds)
+		}
+
+		resolveProtocol()
+		resolveCompression()
+		resolveProxy()
+		if protocol == protocolBoth || compression == compressionBoth {
+			log.Fatalf("❌ ERROR: comparison modes (both) are only supported by the individual method commands")
+		}
+
+		loadAccounts()
+		if err := validateHotSet(); err != nil {
+			log.Fatal(err)
+		}
+		if err := resolveSlot(benchmarkURLs...); err != nil {
+			log.Fatal(err)
+		}
+		startConformance()
+
+		fmt.Fprintf(output, "🏁 Benchmarking %d providers with %d accounts\n", len(benchmarkURLs), len(accounts))
+		fmt.Fprintf(output, "⚙️  Concurrency: %s, Duration: %ds per method\n", benchmarkConcurrencyLabel(), duration)
+		fmt.Fprintf(output, "🔀 Mode: %s\n", suiteModeLabel())
+
+		providers := make([]map[string]TestResult, len(benchmarkURLs))
+		for i, target := range benchmarkURLs {
+			fmt.Fprintf(output, "\n🔄 [%d/%d] Testing %s with %d concurrent requests\n", i+1, len(benchmarkURLs), redactURL(target), benchmarkConcurrency[i])
+			if err := waitUntilReady(target); err != nil {
+				log.Fatal(err)
+			}
+
+			// The suite reads the shared --concurrency, so swap in this provider's allocation for its run
+			sharedConcurrency := concurrency
+			concurrency = benchmarkConcurrency[i]
+			providers[i] = make(map[string]TestResult)
+			for _, result := range runMethodSuite(target, accounts) {
+				providers[i][result.MethodName] = result
+			}
+			concurrency = sharedConcurrency
+		}
+
+		printBenchmarkMatrix(providers)
+		saveBenchmark(providers)
+		exitOnNonConformance()
+	},
+}
+
+// parseBenchmarkTargets splits each --url of the form URL=CONCURRENCY into benchmarkURLs and
+// benchmarkConcurrency, targets without a concurrency get --concurrency
+func parseBenchmarkTargets() {
+	benchmarkConcurrency = make([]int, len(benchmarkURLs))
+	for i, target := range benchmarkURLs {
+		benchmarkConcurrency[i] = concurrency
+
+		// Only a trailing =N counts, so query strings like ?api-key=... pass through untouched
+		if at := strings.LastIndex(target, "="); at >= 0 {
+			if workers, err := strconv.Atoi(target[at+1:]); err == nil {
+				if workers < 1 {
+					log.Fatalf("Invalid --url %s: concurrency must be at least 1", redactURL(target[:at]))
+				}
+				benchmarkURLs[i], benchmarkConcurrency[i] = target[:at], workers
+			}
+		}
+
+		if err := methods.ValidateRPCURL(benchmarkURLs[i]); err != nil {
+			log.Fatalf("Invalid --url: %v", err)
+		}
+	}
+}
+
+// benchmarkConcurrencyLabel returns the shared concurrency, or each provider's when they differ
+func benchmarkConcurrencyLabel() string {
+	var parts []string
+	uniform := true
+	for i, workers := range benchmarkConcurrency {
+		uniform = uniform && workers == benchmarkConcurrency[0]
+		parts = append(parts, fmt.Sprintf("%s=%d", redactURL(benchmarkURLs[i]), workers))
+	}
+	if uniform {
+		return strconv.Itoa(benchmarkConcurrency[0])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// benchmarkWinners returns, per method, the index of the provider with the best value, -1 when none succeeded
+func benchmarkWinners(providers []map[string]TestResult, better func(a, b TestResult) bool) map[string]int {
+	winners := make(map[string]int)
+	for _, method := range runallMethods {
+		winners[method] = -1
+		for i, provider := range providers {
+			result := provider[method]
+			if result.SuccessCount == 0 {
+				continue
+			}
+			if winners[method] == -1 || better(result, providers[winners[method]][method]) {
+				winners[method] = i
+			}
+		}
+	}
+	return winners
+}
+
+// printBenchmarkMatrix prints the provider × method tables for RPS and p95 latency
+func printBenchmarkMatrix(providers []map[string]TestResult) {
+	rpsWinners := benchmarkWinners(providers, func(a, b TestResult) bool { return a.RequestsPerSec > b.RequestsPerSec })
+	p95Winners := benchmarkWinners(providers, func(a, b TestResult) bool { return a.P95Latency < b.P95Latency })
+
+	printTable := func(title string, winners map[string]int, cell func(TestResult) string) {
+		fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		fmt.Fprintln(output, title)
+		fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		fmt.Fprintf(output, "%-36s", "Provider")
+		for _, method := range runallMethods {
+			fmt.Fprintf(output, " %-20s", method)
+		}
+		fmt.Fprintln(output)
+
+		for i, provider := range providers {
+			fmt.Fprintf(output, "%-36s", redactURL(benchmarkURLs[i]))
+			for _, method := range runallMethods {
+				result := provider[method]
+				value := "-"
+				if result.SuccessCount > 0 {
+					value = cell(result)
+				}
+				if winners[method] == i {
+					value += " *"
+				}
+				fmt.Fprintf(output, " %-20s", value)
+			}
+			fmt.Fprintln(output)
+		}
+	}
+
+	printTable("⚡ REQUESTS/SECOND (* highest per method)", rpsWinners, func(result TestResult) string {
+		return fmt.Sprintf("%.2f", result.RequestsPerSec)
+	})
+	printTable("⏱️  P95 LATENCY (* lowest per method)", p95Winners, func(result TestResult) string {
+		return formatLatency(result.P95Latency)
+	})
+}
+
+// saveBenchmark writes the matrix to --output, as CSV when it ends in .csv and JSON otherwise
+func saveBenchmark(providers []map[string]TestResult) {
+	if resultsOutput == "" {
+		return
+	}
+
+	var err error
+	if strings.HasSuffix(resultsOutput, ".csv") {
+		err = writeBenchmarkCSV(providers)
+	} else {
+		err = writeBenchmarkJSON(providers)
+	}
+	if err != nil {
+		fmt.Fprintf(output, "⚠️  Failed to write benchmark to %s: %v\n", resultsOutput, err)
+		return
+	}
+	fmt.Fprintf(output, "💾 Benchmark saved to: %s\n", resultsOutput)
+}
+
+// writeBenchmarkJSON writes every provider's results with the run metadata
+func writeBenchmarkJSON(providers []map[string]TestResult) error {
+	meta := newRunMetadata("benchmark", runallMethods, len(accounts))
+	meta.Mode = suiteMode()
+	var targets []string
+	for _, target := range benchmarkURLs {
+		targets = append(targets, redactURL(target))
+	}
+	meta.RPCURL = strings.Join(targets, ", ")
+
+	file := BenchmarkFile{RunMetadata: meta}
+	for i, provider := range providers {
+		entry := BenchmarkProvider{URL: targets[i], Concurrency: benchmarkConcurrency[i]}
+		for _, method := range runallMethods {
+			if result, ok := provider[method]; ok {
+				entry.Results = append(entry.Results, newMethodResult(result))
+			}
+		}
+		file.Providers = append(file.Providers, entry)
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode benchmark: %v", err)
+	}
+	return os.WriteFile(resultsOutput, data, 0644)
+}
+
+// writeBenchmarkCSV writes one row per provider and method
+func writeBenchmarkCSV(providers []map[string]TestResult) error {
+	rpsWinners := benchmarkWinners(providers, func(a, b TestResult) bool { return a.RequestsPerSec > b.RequestsPerSec })
+	p95Winners := benchmarkWinners(providers, func(a, b TestResult) bool { return a.P95Latency < b.P95Latency })
+
+	out, err := os.Create(resultsOutput)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	writer := csv.NewWriter(out)
+	unit := tableLatencyUnit()
+	writer.Write([]string{"provider", "concurrency", "method", "requests_per_sec", "p95_latency_" + unit, "success_rate", "sla_compliance", "total_requests", "best_rps", "best_p95"})
+	for i, provider := range providers {
+		for _, method := range runallMethods {
+			result, ok := provider[method]
+			if !ok {
+				continue
+			}
+			writer.Write([]string{
+				redactURL(benchmarkURLs[i]),
+				strconv.Itoa(benchmarkConcurrency[i]),
+				method,
+				strconv.FormatFloat(result.RequestsPerSec, 'f', 2, 64),
+				strconv.FormatFloat(latencyIn(result.P95Latency, unit), 'f', 3, 64),
+				strconv.FormatFloat(result.SuccessRate, 'f', 2, 64),
+				slaComplianceCell(result),
+				strconv.FormatInt(result.TotalRequests, 10),
+				strconv.FormatBool(rpsWinners[method] == i),
+				strconv.FormatBool(p95Winners[method] == i),
+			})
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func init() {
+	RootCmd.AddCommand(benchmarkCmd)
+
+	// Repeatable --url replaces the single target of the other commands
+	benchmarkCmd.Flags().BoolVar(&sequentialSuite, "sequential", false, "Run the methods one after another for isolated per-method numbers instead of all at once")
+	benchmarkCmd.Flags().StringArrayVarP(&benchmarkURLs, "url", "u", []string{}, "Target RPC endpoint to benchmark, optionally URL=CONCURRENCY to give it its own number of workers (specify at least twice)")
+}
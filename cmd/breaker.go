@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"rpc_test/methods"
+)
+
+var (
+	breakerThreshold int
+	breakerCooldown  time.Duration
+)
+
+// breakerPollInterval paces workers while the breaker is open so they don't spin
+const breakerPollInterval = 50 * time.Millisecond
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker stops traffic to an endpoint after consecutive transport failures
+type circuitBreaker struct {
+	mu          sync.Mutex
+	state       breakerState
+	failures    int
+	openedAt    time.Time
+	threshold   int
+	cooldown    time.Duration
+	trips       atomic.Int64
+	skipped     atomic.Int64
+	probeActive bool
+}
+
+var (
+	breakersMu sync.Mutex
+	breakers   = map[string]*circuitBreaker{}
+)
+
+// breakerFor returns the shared breaker of a target URL, or nil when --breaker-threshold is 0
+func breakerFor(targetURL string) *circuitBreaker {
+	if breakerThreshold <= 0 {
+		return nil
+	}
+
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	breaker, ok := breakers[targetURL]
+	if !ok {
+		breaker = &circuitBreaker{threshold: breakerThreshold, cooldown: breakerCooldown}
+		breakers[targetURL] = breaker
+	}
+	return breaker
+}
+
+// allow reports whether a request may be sent, letting a single probe through once the cool-off has passed
+func (b *circuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			b.skipped.Add(1)
+			return false
+		}
+		b.state = breakerHalfOpen
+		b.probeActive = true
+		return true
+	case breakerHalfOpen:
+		if b.probeActive {
+			b.skipped.Add(1)
+			return false
+		}
+		b.probeActive = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record updates the breaker with the outcome of a request, only transport failures count against the endpoint
+func (b *circuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil || !methods.IsTransportErrorKind(methods.ClassifyError(err)) {
+		b.state = breakerClosed
+		b.failures = 0
+		b.probeActive = false
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		if b.state != breakerOpen {
+			b.trips.Add(1)
+		}
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+		b.probeActive = false
+	}
+}
+
+// breakerCounts returns how often the breaker opened and how many requests it skipped
+func (b *circuitBreaker) breakerCounts() (trips, skipped int64) {
+	if b == nil {
+		return 0, 0
+	}
+	return b.trips.Load(), b.skipped.Load()
+}
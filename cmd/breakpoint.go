@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"rpc_test/methods"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	rpsStart          float64
+	rpsStep           float64
+	rpsMax            float64
+	rpsInterval       time.Duration
+	targetSuccessRate float64
+	maxP95            time.Duration
+)
+
+// minAchievedRatio is the share of a step's target rate the run must reach for the step to count as healthy
+const minAchievedRatio = 0.9
+
+// BreakpointStep is the health of the endpoint at one target rate
+type BreakpointStep struct {
+	TargetRPS    float64 `json:"target_rps"`
+	AchievedRPS  float64 `json:"achieved_rps"`
+	SuccessRate  float64 `json:"success_rate"`
+	P95LatencyMs float64 `json:"p95_latency_ms"`
+	Healthy      bool    `json:"healthy"`
+	Reason       string  `json:"reason,omitempty"`
+}
+
+// BreakpointFile is the JSON document breakpoint writes to --output
+type BreakpointFile struct {
+	RunMetadata
+	CapacityRPS float64          `json:"capacity_rps"`
+	Steps       []BreakpointStep `json:"steps"`
+}
+
+// breakpointCmd represents the breakpoint command
+var breakpointCmd = &cobra.Command{
+	Use:   "breakpoint <method>",
+	Short: "Ramp the request rate until the endpoint degrades to find its capacity",
+	Long: `Run one method at a fixed request rate for --rps-interval, then raise the rate by --rps-step
+and run again, until a step's success rate drops below --target-success-rate or its p95 exceeds
+--max-p95. The last healthy rate is reported as the endpoint's capacity.
+
+Features:
+• Fixed Rate Steps: Requests are paced at exactly the step's rate, not as fast as workers allow
+• Health per Step: Success rate, p95 and achieved rate are checked after every step
+• Full Curve: Every step is printed, and saved with --output for charting (CSV when it ends in .csv)
+
+Each step needs enough workers to sustain its rate: a step that reaches less than 90% of its
+target counts as unhealthy, so raise --concurrency when latency is low but the rate falls short.
+
+Examples:
+  # Ramp getAccountInfo from 50 RPS in steps of 50 until 99% success or 500ms p95 no longer hold
+  rpc_test breakpoint getAccountInfo --account-file accounts.txt --concurrency 100 --rps-start 50 --rps-step 50 --max-p95 500ms
+
+  # Save the RPS-vs-health curve for charting
+  rpc_test breakpoint getMultipleAccounts --account-file accounts.txt --concurrency 50 --output curve.csv`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		methodName := args[0]
+		if _, ok := methods.LookupMethod(methodName); !ok || methodName == "raw" {
+			log.Fatalf("Unknown method %q, run `rpc_test methods` for the supported ones", methodName)
+		}
+		if rpsStart <= 0 || rpsStep <= 0 {
+			log.Fatalf("--rps-start and --rps-step must be positive")
+		}
+		if rpsInterval < time.Second {
+			log.Fatalf("--rps-interval must be at least 1s, got %s", rpsInterval)
+		}
+		if targetSuccessRate <= 0 || targetSuccessRate > 100 {
+			log.Fatalf("--target-success-rate must be in (0, 100], got %.2f", targetSuccessRate)
+		}
+		if adaptiveRate || slaLatency > 0 {
+			log.Fatalf("breakpoint sets the rate itself and can't be combined with --adaptive-rate or --sla-latency")
+		}
+
+		resolveProtocol()
+		resolveCompression()
+		resolveProxy()
+		if protocol == protocolBoth || compression == compressionBoth {
+			log.Fatalf("❌ ERROR: comparison modes (both) are only supported by the individual method commands")
+		}
+		if err := waitUntilReady(rpcURL); err != nil {
+			log.Fatal(err)
+		}
+		if err := resolveSlot(rpcURL); err != nil {
+			log.Fatal(err)
+		}
+
+		startConformance()
+		defer exitOnNonConformance()
+		if err := startTracing(); err != nil {
+			log.Fatal(err)
+		}
+		defer stopTracing()
+		if err := startErrorLog(); err != nil {
+			log.Fatal(err)
+		}
+		defer stopErrorLog()
+
+		if !parameterlessMethods[methodName] {
+			loadAccounts()
+		}
+		if err := validateHotSet(); err != nil {
+			log.Fatal(err)
+		}
+
+		rpcTest := methods.NewRPCTestWithOptions(rpcURL, apiKey, clientOptions())
+
+		fmt.Fprintf(output, "📈 Finding the breaking point of %s\n", methodName)
+		fmt.Fprintf(output, "RPC URL: %s\n", rpcURL)
+		fmt.Fprintf(output, "Steps: from %.0f RPS by %.0f every %s, %d workers\n", rpsStart, rpsStep, rpsInterval, concurrency)
+		fmt.Fprintf(output, "Healthy: success rate ≥ %.2f%%%s\n", targetSuccessRate, maxP95Label())
+
+		// Every step runs for the interval, the load loop counts whole seconds
+		duration = int(rpsInterval.Round(time.Second).Seconds())
+
+		var steps []BreakpointStep
+		capacity := 0.0
+		for rate := rpsStart; rpsMax <= 0 || rate <= rpsMax; rate += rpsStep {
+			fmt.Fprintf(output, "\n🔄 Step %d: %.0f RPS\n", len(steps)+1, rate)
+			fixedRate = rate
+			result := runMethodLoad(methodName, rpcTest)
+			fmt.Fprintln(output)
+
+			step := breakpointStep(rate, result)
+			steps = append(steps, step)
+			printBreakpointStep(step)
+			if !step.Healthy {
+				break
+			}
+			capacity = rate
+		}
+		fixedRate = 0
+
+		printBreakpointCurve(steps, capacity)
+		saveBreakpoint(methodName, steps, capacity)
+	},
+}
+
+// breakpointStep checks a step's result against the health thresholds
+func breakpointStep(target float64, result TestResult) BreakpointStep {
+	step := BreakpointStep{
+		TargetRPS:    target,
+		AchievedRPS:  result.RequestsPerSec,
+		SuccessRate:  result.SuccessRate,
+		P95LatencyMs: durationMs(result.P95Latency),
+		Healthy:      true,
+	}
+
+	var reasons []string
+	if result.SuccessRate < targetSuccessRate {
+		reasons = append(reasons, fmt.Sprintf("success rate %.2f%% below %.2f%%", result.SuccessRate, targetSuccessRate))
+	}
+	if maxP95 > 0 && result.P95Latency > maxP95 {
+		reasons = append(reasons, fmt.Sprintf("p95 %s above %s", formatLatency(result.P95Latency), formatLatency(maxP95)))
+	}
+	if result.RequestsPerSec < target*minAchievedRatio {
+		reasons = append(reasons, fmt.Sprintf("reached only %.2f RPS", result.RequestsPerSec))
+	}
+	if len(reasons) > 0 {
+		step.Healthy = false
+		step.Reason = strings.Join(reasons, ", ")
+	}
+	return step
+}
+
+// maxP95Label describes the --max-p95 threshold, empty when it is off
+func maxP95Label() string {
+	if maxP95 <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(", p95 ≤ %s", formatLatency(maxP95))
+}
+
+// printBreakpointStep prints the verdict of one step
+func printBreakpointStep(step BreakpointStep) {
+	if step.Healthy {
+		fmt.Fprintf(output, "✅ %.0f RPS healthy: %.2f RPS achieved, %.2f%% success, p95 %.2fms\n",
+			step.TargetRPS, step.AchievedRPS, step.SuccessRate, step.P95LatencyMs)
+		return
+	}
+	fmt.Fprintf(output, "❌ %.0f RPS degraded: %s\n", step.TargetRPS, step.Reason)
+}
+
+// printBreakpointCurve prints every step and the capacity found
+func printBreakpointCurve(steps []BreakpointStep, capacity float64) {
+	fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "📈 RPS vs HEALTH")
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(output, "%-12s %-14s %-12s %-14s %s\n", "Target RPS", "Achieved RPS", "Success %", "P95", "Health")
+	for _, step := range steps {
+		health := "ok"
+		if !step.Healthy {
+			health = step.Reason
+		}
+		fmt.Fprintf(output, "%-12.0f %-14.2f %-12.2f %-14s %s\n", step.TargetRPS, step.AchievedRPS, step.SuccessRate,
+			formatLatency(time.Duration(step.P95LatencyMs*float64(time.Millisecond))), health)
+	}
+
+	switch {
+	case capacity == 0:
+		fmt.Fprintf(output, "\n⚠️  Already degraded at %.0f RPS, lower --rps-start\n", rpsStart)
+	case steps[len(steps)-1].Healthy:
+		fmt.Fprintf(output, "\n🏁 Healthy up to %.0f RPS, the --rps-max limit; capacity is at least that\n", capacity)
+	default:
+		fmt.Fprintf(output, "\n🏁 Capacity: %.0f RPS\n", capacity)
+	}
+}
+
+// saveBreakpoint writes the curve to --output, as CSV when it ends in .csv and JSON otherwise
+func saveBreakpoint(methodName string, steps []BreakpointStep, capacity float64) {
+	if resultsOutput == "" {
+		return
+	}
+
+	var err error
+	if strings.HasSuffix(resultsOutput, ".csv") {
+		err = writeBreakpointCSV(steps)
+	} else {
+		file := BreakpointFile{
+			RunMetadata: newRunMetadata("breakpoint", []string{methodName}, len(accounts)),
+			CapacityRPS: capacity,
+			Steps:       steps,
+		}
+		var data []byte
+		if data, err = json.MarshalIndent(file, "", "  "); err == nil {
+			err = os.WriteFile(resultsOutput, data, 0644)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(output, "⚠️  Failed to write breakpoint curve to %s: %v\n", resultsOutput, err)
+		return
+	}
+	fmt.Fprintf(output, "💾 Breakpoint curve saved to: %s\n", resultsOutput)
+}
+
+// writeBreakpointCSV writes one row per step
+func writeBreakpointCSV(steps []BreakpointStep) error {
+	out, err := os.Create(resultsOutput)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	writer := csv.NewWriter(out)
+	writer.Write([]string{"target_rps", "achieved_rps", "success_rate", "p95_latency_ms", "healthy", "reason"})
+	for _, step := range steps {
+		writer.Write([]string{
+			strconv.FormatFloat(step.TargetRPS, 'f', 2, 64),
+			strconv.FormatFloat(step.AchievedRPS, 'f', 2, 64),
+			strconv.FormatFloat(step.SuccessRate, 'f', 2, 64),
+			strconv.FormatFloat(step.P95LatencyMs, 'f', 3, 64),
+			strconv.FormatBool(step.Healthy),
+			step.Reason,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func init() {
+	RootCmd.AddCommand(breakpointCmd)
+
+	breakpointCmd.Flags().Float64Var(&rpsStart, "rps-start", 10, "Requests per second of the first step")
+	breakpointCmd.Flags().Float64Var(&rpsStep, "rps-step", 10, "Requests per second added at every step")
+	breakpointCmd.Flags().Float64Var(&rpsMax, "rps-max", 0, "Stop after the step at this rate even if it is healthy (0 ramps until the endpoint degrades)")
+	breakpointCmd.Flags().DurationVar(&rpsInterval, "rps-interval", 10*time.Second, "How long each step runs, in whole seconds")
+	breakpointCmd.Flags().Float64Var(&targetSuccessRate, "target-success-rate", 99, "Lowest success rate, in percent, of a healthy step")
+	breakpointCmd.Flags().DurationVar(&maxP95, "max-p95", 0, "Highest p95 latency of a healthy step (0 only checks the success rate)")
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	cleanAccounts bool
+	cleanResults  bool
+	cleanTemp     bool
+	cleanAll      bool
+	cleanDryRun   bool
+)
+
+// cleanCategory is one kind of artifact clean removes from --data-dir, by file name pattern
+type cleanCategory struct {
+	Name     string
+	Patterns []string
+	Skip     string // pattern of files matching Patterns that belong to another category
+}
+
+var (
+	// cleanAccountFiles are seeded account lists, such as runall's test_accounts.txt
+	cleanAccountFiles = cleanCategory{Name: "accounts", Patterns: []string{"*.txt"}, Skip: "server_accounts_*.txt"}
+
+	// cleanResultFiles are results, logs and traces saved into the data directory
+	cleanResultFiles = cleanCategory{Name: "results", Patterns: []string{"*.json", "*.jsonl", "*.csv", "*.jsonl.1"}}
+
+	// cleanTempFiles are the per-test accounts files of the benchmark server
+	cleanTempFiles = cleanCategory{Name: "temp", Patterns: []string{"server_accounts_*.txt"}}
+)
+
+// cleanKept are never removed, even when they match a category
+var cleanKept = map[string]bool{"config.json": true, "config-template.json": true}
+
+// cleanCmd represents the clean command
+var cleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove generated files from the data directory",
+	Long: `Remove the accounts, results and temp files repeated runs leave in --data-dir, and report
+how many files and bytes were freed.
+
+Only regular files directly inside --data-dir are removed: subdirectories, symlinks and
+anything outside the directory are never touched.
+
+Categories:
+• --accounts: Seeded account lists (*.txt), such as runall's test_accounts.txt
+• --results: Results, logs and traces (*.json, *.jsonl, *.csv and rotated *.jsonl.1)
+• --temp: Per-test accounts files of the benchmark server (server_accounts_*.txt)
+
+Examples:
+  # See what would be removed
+  rpc_test clean --all --dry-run
+
+  # Remove seeded accounts so the next runall seeds afresh
+  rpc_test clean --accounts`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var categories []cleanCategory
+		if cleanAccounts || cleanAll {
+			categories = append(categories, cleanAccountFiles)
+		}
+		if cleanResults || cleanAll {
+			categories = append(categories, cleanResultFiles)
+		}
+		if cleanTemp || cleanAll {
+			categories = append(categories, cleanTempFiles)
+		}
+		if len(categories) == 0 {
+			log.Fatalf("Nothing to clean, choose --accounts, --results, --temp or --all")
+		}
+
+		root, err := filepath.Abs(dataDir)
+		if err != nil {
+			log.Fatalf("Failed to resolve data directory %s: %v", dataDir, err)
+		}
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			fmt.Fprintf(output, "🧹 Nothing to clean, %s does not exist\n", root)
+			return
+		}
+		if reason := unsafeCleanDir(root); reason != "" {
+			log.Fatalf("Refusing to clean %s: %s, point --data-dir at a directory used only by rpc_test", root, reason)
+		}
+
+		verb := "Removed"
+		if cleanDryRun {
+			verb = "Would remove"
+			fmt.Fprintf(output, "🔍 Dry run, nothing is deleted\n")
+		}
+
+		var files int
+		var freed int64
+		for _, category := range categories {
+			for _, path := range cleanCandidates(root, category) {
+				info, err := os.Lstat(path)
+				if err != nil || !info.Mode().IsRegular() {
+					continue
+				}
+				if !cleanDryRun {
+					if err := os.Remove(path); err != nil {
+						fmt.Fprintf(output, "⚠️  Failed to remove %s: %v\n", path, err)
+						continue
+					}
+				}
+				fmt.Fprintf(output, "   %s %s (%s, %s)\n", verb, path, category.Name, formatBytes(info.Size()))
+				files++
+				freed += info.Size()
+			}
+		}
+
+		fmt.Fprintf(output, "🧹 %s %d files from %s, %s freed\n", verb, files, root, formatBytes(freed))
+	},
+}
+
+// cleanCandidates returns the files of category directly inside root, never a path outside it
+func cleanCandidates(root string, category cleanCategory) []string {
+	var paths []string
+	for _, pattern := range category.Patterns {
+		matches, err := filepath.Glob(filepath.Join(root, pattern))
+		if err != nil {
+			continue
+		}
+		for _, path := range matches {
+			if cleanKept[filepath.Base(path)] {
+				continue
+			}
+			if category.Skip != "" {
+				if skip, _ := filepath.Match(category.Skip, filepath.Base(path)); skip {
+					continue
+				}
+			}
+			if !insideDir(root, path) {
+				continue
+			}
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// unsafeCleanDir explains why root holds more than rpc_test's files, empty when it can be cleaned
+func unsafeCleanDir(root string) string {
+	if filepath.Dir(root) == root {
+		return "it is the filesystem root"
+	}
+	if home, err := os.UserHomeDir(); err == nil && filepath.Clean(home) == root {
+		return "it is the home directory"
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(root, cwd); err == nil && !strings.HasPrefix(rel, "..") {
+			return "it holds the working directory"
+		}
+	}
+	return ""
+}
+
+// insideDir reports whether path is directly inside dir
+func insideDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != "." && !strings.HasPrefix(rel, "..") && !strings.ContainsRune(rel, filepath.Separator)
+}
+
+func init() {
+	RootCmd.AddCommand(cleanCmd)
+
+	cleanCmd.Flags().BoolVar(&cleanAccounts, "accounts", false, "Remove seeded account lists (*.txt)")
+	cleanCmd.Flags().BoolVar(&cleanResults, "results", false, "Remove results, logs and traces (*.json, *.jsonl, *.csv)")
+	cleanCmd.Flags().BoolVar(&cleanTemp, "temp", false, "Remove the benchmark server's per-test accounts files")
+	cleanCmd.Flags().BoolVar(&cleanAll, "all", false, "Remove every category")
+	cleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "List what would be removed without deleting anything")
+}
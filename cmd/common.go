@@ -1,15 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"math/rand"
-	"os"
 	"strings"
 	"sync"
 	"time"
 
 	"rpc_test/methods"
+
+	"github.com/gagliardetto/solana-go/rpc"
 )
 
 // Common variables for all commands
@@ -21,192 +22,687 @@ var (
 	accountsFile string
 	limit        int
 	apiKey       string
+	protocol     string
+	forceHTTP2   bool
+	compression  string
+	proxyAddr    string
+	otelEndpoint string
+	userAgent    string
+
+	connectTimeout   time.Duration
+	requestTimeout   time.Duration
+	progressInterval time.Duration
+
+	// connections bounds the client to this many connections to the target, independent of --concurrency
+	connections int
+
+	// reconnectOnError drops the pooled connections after a connection reset instead of reusing them
+	reconnectOnError bool
+
+	// validateData fails getMultipleAccounts responses whose account data does not decode
+	validateData bool
+
+	// requireNonNull fails getMultipleAccounts responses with fewer non-null accounts than this percent
+	requireNonNull float64
 )
 
-func Method(name string, rpcTest *methods.RPCTest, account ...string) error {
-	switch name {
-	case "getAccountInfo":
-		return rpcTest.GetAccountInfo(account[0])
-	case "getMultipleAccounts":
-		return rpcTest.GetMultipleAccounts(account...)
-	case "getProgramAccounts":
-		return rpcTest.GetProgramAccounts(account[0])
-	default:
-		return fmt.Errorf("invalid method: %s", name)
+// minProgressInterval keeps the progress display from flickering
+const minProgressInterval = 100 * time.Millisecond
+
+// progressRefresh resolves --progress-interval, keeping the display's own cadence when the flag isn't set
+// and returning 0 when progress output is disabled
+func progressRefresh(defaultInterval time.Duration) time.Duration {
+	if !RootCmd.PersistentFlags().Changed("progress-interval") {
+		return defaultInterval
+	}
+	if progressInterval <= 0 {
+		return 0
+	}
+	if progressInterval < minProgressInterval {
+		return minProgressInterval
 	}
+	return progressInterval
 }
 
-// RunMethodTest runs a performance test for a specific RPC method
-func RunMethodTest(methodName string) {
+// batchMethods take a batch of 5-14 accounts per request
+var batchMethods = methods.MethodsWithArgs(methods.ArgsBatch)
+
+// parameterlessMethods take no account or program arguments
+var parameterlessMethods = methods.MethodsWithArgs(methods.ArgsNone)
+
+// programMethods take a program address per request
+var programMethods = methods.MethodsWithArgs(methods.ArgsProgram)
+
+// validateCallOptions checks the method flags callOptions reads
+func validateCallOptions() error {
+	if requireNonNull < 0 || requireNonNull > 100 {
+		return fmt.Errorf("--require-nonnull-ratio must be in [0, 100], got %.2f", requireNonNull)
+	}
+	return nil
+}
+
+// callOptions collects the method flags into the options of dispatched calls
+func callOptions() methods.CallOptions {
+	if err := validateCallOptions(); err != nil {
+		log.Fatal(err)
+	}
+
+	return methods.CallOptions{
+		CountOnly:      countOnly,
+		StreamDecode:   streamDecode,
+		ValidateData:   validateData,
+		Strict:         strictMode,
+		MinContextSlot: pinnedSlot,
+		RequireNonNull: requireNonNull,
+		InflationEpoch: inflationEpoch,
+		FeeMessage:     feeMessage,
+		FeeCommitment:  rpc.CommitmentType(feeCommitment),
+		RawMethod:      rawMethod,
+		RawParams:      rawParamList,
+	}
+}
+
+// loadAccounts merges --accounts and --account-file into accounts and applies --account-offset and --limit
+func loadAccounts() {
+	accounts = append(accounts, accountList...)
+
 	// Load accounts from file if provided
 	if accountsFile != "" {
-		data, err := os.ReadFile(accountsFile)
+		lines, err := methods.ReadAccountFile(accountsFile)
 		if err != nil {
 			log.Fatalf("Failed to read accounts file: %v", err)
 		}
-		// Parse accounts from file
-		lines := strings.Split(string(data), "\n")
-		for _, line := range lines {
-			line = strings.TrimSpace(line)
-			if line != "" {
-				accounts = append(accounts, line)
-			}
-		}
+		accounts = append(accounts, lines...)
 	}
 
 	if len(accounts) == 0 {
 		log.Fatalf("No accounts provided. Use --account or --account-file to specify accounts")
 	}
 
-	// Apply limit if specified
+	// Keep only this machine's shard before applying the limit
+	if err := validateShard(); err != nil {
+		log.Fatal(err)
+	}
+	accounts = shardAccounts(accounts)
+	if len(accounts) == 0 {
+		log.Fatalf("Shard %d/%d has no accounts, use fewer shards or more accounts", shardIndex, shardCount)
+	}
+
+	// Apply the offset and limit if specified
 	totalAccounts := len(accounts)
-	if limit > 0 && limit < totalAccounts {
-		accounts = accounts[:limit]
-		fmt.Printf("Limiting to %d accounts out of %d available\n", limit, totalAccounts)
+	window, err := accountWindow(accounts, accountOffset, limit)
+	if err != nil {
+		log.Fatalf("Invalid --account-offset: %v", err)
+	}
+	accounts = window
+	if accountOffset > 0 {
+		fmt.Fprintf(output, "Using accounts [%d:%d] out of %d available\n", accountOffset, accountOffset+len(accounts), totalAccounts)
+	} else if len(accounts) < totalAccounts {
+		fmt.Fprintf(output, "Limiting to %d accounts out of %d available\n", limit, totalAccounts)
+	}
+}
+
+// RunMethodTest runs a performance test for a specific RPC method
+func RunMethodTest(methodName string) {
+	resolveProtocol()
+	resolveCompression()
+	resolveProxy()
+	if replayPath != "" {
+		runReplay(rpcURL, methodName)
+		return
+	}
+	confirmLoad(rpcURL, concurrency, plannedDuration(RootCmd.PersistentFlags().Changed("duration")))
+	if err := waitUntilReady(rpcURL); err != nil {
+		log.Fatal(err)
+	}
+	if err := resolveSlot(rpcURL); err != nil {
+		log.Fatal(err)
+	}
+
+	// Deferred first so the report, which may exit, comes after everything else
+	startConformance()
+	defer exitOnNonConformance()
+	startSlowest()
+	if err := startTracing(); err != nil {
+		log.Fatal(err)
+	}
+	defer stopTracing()
+	if err := startErrorLog(); err != nil {
+		log.Fatal(err)
+	}
+	defer stopErrorLog()
+	if err := startRecording(); err != nil {
+		log.Fatal(err)
+	}
+	defer stopRecording()
+
+	if err := startSoak(RootCmd.PersistentFlags().Changed("duration")); err != nil {
+		log.Fatal(err)
+	}
+	defer stopSoak()
+
+	if !parameterlessMethods[methodName] {
+		loadAccounts()
+	}
+	if err := validateHotSet(); err != nil {
+		log.Fatal(err)
+	}
+
+	if protocol == protocolBoth {
+		compareProtocols(methodName)
+		return
+	}
+	if compression == compressionBoth {
+		compareCompression(methodName)
+		return
 	}
 
 	// Create RPC client
-	rpcTest := methods.NewRPCTest(rpcURL, apiKey)
+	rpcTest := methods.NewRPCTestWithOptions(rpcURL, apiKey, clientOptions())
+	applyProgramFilters(rpcTest)
 
 	// Run the stress test
-	fmt.Printf("Starting %s test with %d concurrent requests for %d seconds\n",
+	fmt.Fprintf(output, "Starting %s test with %d concurrent requests for %d seconds\n",
 		methodName, concurrency, duration)
-	fmt.Printf("RPC URL: %s\n", rpcURL)
-	fmt.Printf("Number of accounts: %d\n", len(accounts))
+	fmt.Fprintf(output, "RPC URL: %s\n", rpcURL)
+	fmt.Fprintf(output, "Protocol: %s\n", protocolLabel(protocol))
+	fmt.Fprintf(output, "Proxy: %s\n", proxyLabel())
+	if !parameterlessMethods[methodName] {
+		fmt.Fprintf(output, "Number of accounts: %d\n", len(accounts))
+		if hotSize := hotSetSize(len(accounts)); hotSize > 0 {
+			fmt.Fprintf(output, "Hot set: %.0f%% of requests target the hottest %d accounts\n", hotRatio*100, hotSize)
+		}
+		if workers := disjointWorkers(len(accounts)); workers > 0 {
+			fmt.Fprintf(output, "Unique per worker: each worker reads its own %d accounts\n", len(accounts)/workers)
+		}
+		if warning := accountSharingWarning(len(accounts)); warning != "" {
+			fmt.Fprintf(output, "⚠️  %s\n", warning)
+		}
+	}
+
+	logConfigLoaded(methodName)
+	accountCount := 0
+	if !parameterlessMethods[methodName] {
+		accountCount = len(accounts)
+	}
+	meta := newRunMetadata(methodName, []string{methodName}, accountCount)
+
+	var perfBefore *rpc.GetRecentPerformanceSamplesResult
+	if crossCheckPerf {
+		perfBefore = capturePerfSample("before")
+	}
+
+	if err := startResources(); err != nil {
+		log.Fatal(err)
+	}
+	result := runMethodLoad(methodName, rpcTest)
+	stopResources()
+	fmt.Fprintln(output)
+	printRunMetadata(meta)
+	printMethodSummary(result, rpcTest)
+	printSlowest()
+	printResources()
+	saveResults(meta, []TestResult{result})
+
+	if crossCheckPerf {
+		printPerfCrossCheck(perfBefore, capturePerfSample("after"), []TestResult{result})
+	}
+}
+
+// runMethodLoad drives methodName against rpcTest for the configured duration and collects statistics
+func runMethodLoad(methodName string, rpcTest *methods.RPCTest) TestResult {
+	return runMethodLoadOn(methodName, rpcTest, loadTarget{url: rpcURL, accounts: accounts})
+}
+
+// loadTarget is the endpoint and accounts a method load runs against
+type loadTarget struct {
+	url      string
+	accounts []string
+
+	// progress is runall's shared display, nil to draw the method's own progress bar
+	progress *ProgressManager
+}
+
+// runMethodLoadOn drives methodName against rpcTest with the accounts of target and collects statistics
+func runMethodLoadOn(methodName string, rpcTest *methods.RPCTest, target loadTarget) TestResult {
+	logEvent("method_started", "method", methodName)
+
+	accounts := target.accounts
+	if len(accounts) == 0 && !parameterlessMethods[methodName] {
+		return noAccountsResult(methodName)
+	}
+	switch methodName {
+	case "getFeeForMessage":
+		prepareFeeMessage(rpcTest)
+	case "raw":
+		probeRawMethod(rpcTest)
+	case "getProgramAccounts":
+		if countOnly {
+			probeCountOnly(rpcTest)
+		}
+	}
+	rpcTest.SetCallOptions(callOptions())
 
 	startTime := time.Now()
 	endTime := startTime.Add(time.Duration(duration) * time.Second)
 
 	var wg sync.WaitGroup
 	var successCount, failureCount int64
+	var emptyCount, partialCount int64
+	var countedAccounts int64
+	var slaCompliant int64
+	var slotLagTotal, slotLagSamples, staleResponses int64
+	var retryCount int64
 	var mutex sync.Mutex
 
 	// Create channels for workers
 	stop := make(chan struct{})
 
+	// tip follows the chain tip under --track-slot, nil when it is off
+	tip := startSlotTip(target.url, stop)
+
 	// Collect statistics
 	var totalLatency time.Duration
 	var minLatency time.Duration = time.Hour
 	var maxLatency time.Duration
+	var latencies []time.Duration
 
-	// Start workers
-	for i := 0; i < concurrency; i++ {
-		wg.Add(1)
-		go func(workerID int) {
-			defer wg.Done()
+	errorKinds := make(map[string]int64)
+	errorCodes := make(map[int]*RPCErrorCount)
 
-			for {
-				select {
-				case <-stop:
+	breaker := breakerFor(target.url)
+	tripsBefore, skippedBefore := breaker.breakerCounts()
+
+	var hotStats hotSetStats
+	var batches *batchPool
+	if batchMethods[methodName] {
+		batches = newBatchPool(accounts, &hotStats)
+	}
+
+	// pool resizes the workers under --sla-latency, nil for fixed concurrency
+	var pool *adaptivePool
+
+	// limiter paces requests under --adaptive-rate, nil when requests go out as fast as workers allow
+	limiter := newRateLimiter()
+	if limiter != nil {
+		go limiter.run(stop)
+	}
+
+	// worker sends requests until the run ends or quit is closed (nil for fixed concurrency)
+	worker := func(workerID int, quit <-chan struct{}) {
+		defer wg.Done()
+
+		picker := newAccountPicker(accounts, workerID, runSeed, &hotStats)
+		requestIndex := 0
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-quit:
+				return
+			default:
+				// Check if test duration has elapsed
+				if time.Now().After(endTime) {
 					return
-				default:
-					// Check if test duration has elapsed
-					if time.Now().After(endTime) {
-						return
-					}
+				}
 
-					// Execute the specified method
-					startReq := time.Now()
-					var err error
-					if methodName == "getMultipleAccounts" {
-						numAccounts := rand.Intn(10) + 5
-						if len(accounts) < numAccounts {
-							numAccounts = len(accounts)
-						}
-						var batchAccounts []string
-						for i := 0; i < numAccounts; i++ {
-							accountIndex := (workerID + i) % len(accounts)
-							batchAccounts = append(batchAccounts, accounts[accountIndex])
-						}
-						err = Method(methodName, rpcTest, batchAccounts...)
+				// Hold off while the endpoint's circuit breaker is open
+				if !breaker.allow() {
+					time.Sleep(breakerPollInterval)
+					continue
+				}
+
+				// Execute the specified method
+				ctx := context.Background()
+				var stats *methods.ResponseStats
+				if tracer != nil {
+					ctx, stats = methods.WithResponseStats(ctx)
+				}
+
+				// Pick the accounts before starting the clock so only the request is measured
+				var args []string
+				if batchMethods[methodName] {
+					if batches != nil {
+						args = batches.take(workerID, requestIndex)
 					} else {
-						err = Method(methodName, rpcTest, accounts[workerID%len(accounts)])
+						args = picker.batch(randomBatchSize(picker.rng, len(accounts)))
 					}
-					reqDuration := time.Since(startReq)
+				} else if !parameterlessMethods[methodName] {
+					args = []string{picker.single()}
+				}
+				requestIndex++
 
-					mutex.Lock()
-					if err != nil {
-						failureCount++
-					} else {
-						successCount++
-						totalLatency += reqDuration
-						if reqDuration < minLatency {
-							minLatency = reqDuration
-						}
-						if reqDuration > maxLatency {
-							maxLatency = reqDuration
+				if !limiter.wait(stop, quit) {
+					return
+				}
+
+				// A retried request is timed across all its attempts
+				startReq := time.Now()
+				outcome, retried, err := dispatchWithRetries(ctx, methodName, rpcTest, args, picker.rng, endTime)
+				reqDuration := time.Since(startReq)
+				limiter.record(err)
+				pool.record(startReq.Add(reqDuration), reqDuration)
+				soak.record(reqDuration, err)
+				failureLogger.record(methodName, args, reqDuration, err)
+				slowest.record(methodName, args, startReq, reqDuration, err)
+				recorder.record(methodName, args, startReq, err)
+				conformance.record(err)
+
+				if tracer != nil {
+					tracer.record(methodName, startReq, reqDuration, stats.DecodedBytes.Load(), err)
+				}
+				breaker.record(err)
+
+				mutex.Lock()
+				retryCount += int64(retried)
+				if err != nil {
+					// runall's display has no live error counts, so it reports each failure as it happens
+					if target.progress != nil {
+						fmt.Fprintf(output, "  ❌ Error: %v\n", err)
+						logWarn("request_failed", err, "method", methodName)
+					}
+					failureCount++
+					errorKinds[methods.ClassifyError(err)]++
+					tallyErrorCode(errorCodes, err)
+				} else {
+					successCount++
+					if outcome.Empty {
+						emptyCount++
+					}
+					if outcome.Partial {
+						partialCount++
+					}
+					countedAccounts += int64(outcome.Counted)
+					totalLatency += reqDuration
+					if withinSLA(reqDuration) {
+						slaCompliant++
+					}
+					if lag, ok := tip.lag(outcome.ContextSlot); ok {
+						slotLagTotal += int64(lag)
+						slotLagSamples++
+						if lag > maxSlotLag {
+							staleResponses++
 						}
 					}
-					mutex.Unlock()
+					latencies = retainLatency(latencies, reqDuration, successCount)
+					if reqDuration < minLatency {
+						minLatency = reqDuration
+					}
+					if reqDuration > maxLatency {
+						maxLatency = reqDuration
+					}
 				}
+				mutex.Unlock()
 			}
-		}(i)
+		}
+	}
+
+	// Start workers, a fixed pool or one resized by the --sla-latency controller
+	if slaLatency > 0 {
+		pool = newAdaptivePool(time.Duration(slaLatency)*time.Millisecond, func(workerID int, quit <-chan struct{}) {
+			wg.Add(1)
+			go worker(workerID, quit)
+		}, func() int64 {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return successCount + failureCount
+		})
+		go pool.run(concurrency, stop)
+	} else {
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go worker(i, nil)
+		}
 	}
 
-	// Add progress reporting
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
+	// Add progress reporting, to runall's shared display or to the method's own bar
+	if target.progress != nil {
+		// Update the display at least as often as it redraws
+		progressTicker := time.NewTicker(500 * time.Millisecond)
+		if interval := progressRefresh(2 * time.Second); interval > 0 && interval < 500*time.Millisecond {
+			progressTicker.Reset(interval)
+		}
+		defer progressTicker.Stop()
 
-	go func() {
-		fmt.Println("\nProgress:")
-		for {
-			select {
-			case <-ticker.C:
-				if time.Now().After(endTime) {
+		go func() {
+			for {
+				select {
+				case <-progressTicker.C:
+					if time.Now().After(endTime) {
+						return
+					}
+					mutex.Lock()
+					target.progress.UpdateProgress(methodName, successCount, failureCount, totalLatency)
+					mutex.Unlock()
+				case <-stop:
 					return
 				}
+			}
+		}()
+	} else if interval := progressRefresh(1 * time.Second); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
 
-				mutex.Lock()
-				elapsed := time.Since(startTime)
-				currentTotal := successCount + failureCount
-				currentRPS := float64(currentTotal) / elapsed.Seconds()
-				percentComplete := (elapsed.Seconds() / float64(duration)) * 100
-
-				// Create a simple progress bar
-				const barWidth = 30
-				progress := int(percentComplete * float64(barWidth) / 100)
-				progressBar := strings.Repeat("█", progress) + strings.Repeat("░", barWidth-progress)
-
-				fmt.Printf("\r[%s] %.1f%% | %ds/%ds | Requests: %d | RPS: %.1f",
-					progressBar, percentComplete, int(elapsed.Seconds()), duration, currentTotal, currentRPS)
-				mutex.Unlock()
-			case <-stop:
-				return
+		go func() {
+			var window liveWindow
+			if !progressJSON {
+				fmt.Fprintln(output, "\nProgress:")
 			}
-		}
-	}()
+			for {
+				select {
+				case <-ticker.C:
+					if time.Now().After(endTime) {
+						return
+					}
+
+					mutex.Lock()
+					elapsed := time.Since(startTime)
+					if progressJSON {
+						emitProgressJSON(methodName, elapsed.Seconds()/float64(duration)*100, successCount, failureCount, elapsed)
+						mutex.Unlock()
+						continue
+					}
+					currentTotal := successCount + failureCount
+					currentRPS := float64(currentTotal) / elapsed.Seconds()
+					snapshot := liveSnapshot{at: time.Now(), requests: currentTotal, successes: successCount, totalLatency: totalLatency}
+					windowRPS, windowLatency := window.rates(snapshot)
+					window.add(snapshot)
+					percentComplete := (elapsed.Seconds() / float64(duration)) * 100
+
+					// Create a simple progress bar
+					const barWidth = 30
+					progress := int(percentComplete * float64(barWidth) / 100)
+					progressBar := strings.Repeat("█", progress) + strings.Repeat("░", barWidth-progress)
+
+					fmt.Fprintf(output, "\r[%s] %.1f%% | %ds/%ds | Requests: %d | RPS: %.1f now, %.1f avg | Latency: %s now",
+						progressBar, percentComplete, int(elapsed.Seconds()), duration, currentTotal, windowRPS, currentRPS, formatLatency(windowLatency))
+					if pool != nil {
+						fmt.Fprintf(output, " | Workers: %d", pool.workers())
+					}
+					mutex.Unlock()
+				case <-stop:
+					return
+				}
+			}
+		}()
+	}
 
 	// Wait for the test duration
 	time.Sleep(time.Duration(duration) * time.Second)
 	close(stop)
 
-	// Wait for all workers to finish
+	// Wait for the controller to stop resizing, then for all workers to finish
+	if pool != nil {
+		<-pool.done
+	}
+	if limiter != nil {
+		<-limiter.done
+	}
 	wg.Wait()
 
-	// Calculate and display results
+	// Final progress update
+	if target.progress != nil {
+		target.progress.UpdateProgress(methodName, successCount, failureCount, totalLatency)
+	}
+
+	// Calculate results
 	totalDuration := time.Since(startTime)
 	totalRequests := successCount + failureCount
 	requestsPerSecond := float64(totalRequests) / totalDuration.Seconds()
 	successRate := float64(successCount) / float64(totalRequests) * 100
 
+	var avgLatency time.Duration
+	if successCount > 0 {
+		avgLatency = totalLatency / time.Duration(successCount)
+	}
+
+	transfer := rpcTest.TransferStats()
+	conns := rpcTest.ConnectionStats()
+	trips, skipped := breaker.breakerCounts()
+
+	result := TestResult{
+		MethodName:           methodName,
+		Duration:             totalDuration,
+		TotalRequests:        totalRequests,
+		SuccessCount:         successCount,
+		EmptyCount:           emptyCount,
+		PartialResponseCount: partialCount,
+		CountedAccounts:      countedAccounts,
+		SLACompliant:         slaCompliant,
+		SlotLagSamples:       slotLagSamples,
+		StaleResponses:       staleResponses,
+		Retries:              retryCount,
+		FailureCount:         failureCount,
+		RequestsPerSec:       requestsPerSecond,
+		SuccessRate:          successRate,
+		MinLatency:           minLatency,
+		MaxLatency:           maxLatency,
+		AvgLatency:           avgLatency,
+		P50Latency:           percentile(latencies, 50),
+		P95Latency:           percentile(latencies, 95),
+		WireBytes:            transfer.WireBytes,
+		DecodedBytes:         transfer.DecodedBytes,
+		HotSetHits:           hotStats.hits.Load(),
+		AccountPicks:         hotStats.picks.Load(),
+		ErrorKinds:           errorKinds,
+		ErrorCodes:           errorCodes,
+		BreakerTrips:         trips - tripsBefore,
+		SkippedByBreaker:     skipped - skippedBefore,
+		ConnectionsOpened:    conns.Opened,
+		ConnectionWaits:      conns.Waits,
+		ConnectionWaitTime:   conns.WaitTime,
+		Reconnects:           conns.Reconnects,
+	}
+	if pool != nil {
+		result.SLAConcurrency, result.SLARequestsPerSec = pool.steadyState()
+	}
+	if slotLagSamples > 0 {
+		result.AvgSlotLag = float64(slotLagTotal) / float64(slotLagSamples)
+	}
+	if limiter != nil && !limiter.fixed {
+		result.SettledRate = limiter.settled()
+	}
+
+	logMethodFinished(result)
+	return result
+}
+
+// printMethodSummary displays the results of a single method test
+func printMethodSummary(result TestResult, rpcTest *methods.RPCTest) {
 	// Improved results formatting with clearer visual separation
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println("📊 TEST RESULTS SUMMARY")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Printf("🕒 Duration:         %.2f seconds\n", totalDuration.Seconds())
-	fmt.Printf("🔢 Total Requests:    %d\n", totalRequests)
-	fmt.Printf("✅ Successful:        %d (%.2f%%)\n", successCount, successRate)
-	fmt.Printf("❌ Failed:            %d (%.2f%%)\n", failureCount, 100-successRate)
-	fmt.Printf("⚡ Requests/second:   %.2f\n", requestsPerSecond)
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "📊 TEST RESULTS SUMMARY")
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if result.Error != "" {
+		fmt.Fprintf(output, "❌ Not run:           %s\n", result.Error)
+		return
+	}
+	fmt.Fprintf(output, "🕒 Duration:         %.2f seconds\n", result.Duration.Seconds())
+	fmt.Fprintf(output, "🌐 Protocol:          %s\n", rpcTest.NegotiatedProtocol())
+	fmt.Fprintf(output, "🔢 Total Requests:    %d\n", result.TotalRequests)
+	fmt.Fprintf(output, "✅ Successful:        %d (%.2f%%)\n", result.SuccessCount, result.SuccessRate)
+	if result.EmptyCount > 0 {
+		fmt.Fprintf(output, "   Empty (null):      %d (%.2f%% of successes, null responses are artificially fast)\n",
+			result.EmptyCount, float64(result.EmptyCount)/float64(result.SuccessCount)*100)
+	}
+	if result.PartialResponseCount > 0 {
+		fmt.Fprintf(output, "   Partial:           %d (fewer non-null accounts returned than requested)\n", result.PartialResponseCount)
+	}
+	fmt.Fprintf(output, "❌ Failed:            %d (%.2f%%)\n", result.FailureCount, 100-result.SuccessRate)
+	if result.FailureCount > 0 {
+		transportFailures, rpcFailures := splitFailures(result.ErrorKinds)
+		fmt.Fprintf(output, "   Transport:         %d (endpoint/network)\n", transportFailures)
+		fmt.Fprintf(output, "   RPC:               %d (request/params)\n", rpcFailures)
+		if malformed := result.ErrorKinds[methods.ErrorKindDecode]; malformed > 0 {
+			fmt.Fprintf(output, "   Malformed:         %d (answered, but the account data did not decode)\n", malformed)
+		}
+		if behind := result.ErrorKinds[methods.ErrorKindSlotNotReached]; behind > 0 {
+			fmt.Fprintf(output, "   Slot not reached:  %d (the endpoint has not caught up to slot %d)\n", behind, pinnedSlot)
+		}
+		if nulls := result.ErrorKinds[methods.ErrorKindNullAccounts]; nulls > 0 {
+			fmt.Fprintf(output, "   Too many nulls:    %d (fewer than %.2f%% of the batch came back non-null)\n", nulls, requireNonNull)
+		}
+		if nonconforming := result.ErrorKinds[methods.ErrorKindConformance]; nonconforming > 0 {
+			fmt.Fprintf(output, "   Nonconforming:     %d (answered, but not in the reference RPC's shape)\n", nonconforming)
+		}
+	}
+	if breakdown := formatErrorBreakdown(result.ErrorKinds); breakdown != "" {
+		fmt.Fprintf(output, "   Errors:            %s\n", breakdown)
+	}
+	printErrorCodes(result.ErrorCodes, "   ")
+	if result.BreakerTrips > 0 {
+		fmt.Fprintf(output, "⛔ Breaker:           opened %d times, %d requests skipped\n", result.BreakerTrips, result.SkippedByBreaker)
+	}
+	if result.Retries > 0 {
+		fmt.Fprintf(output, "🔁 Retries:           %d (transport failures re-sent, up to %d per request)\n", result.Retries, retries)
+	}
+	fmt.Fprintf(output, "⚡ Requests/second:   %.2f\n", result.RequestsPerSec)
+	if result.SettledRate > 0 {
+		fmt.Fprintf(output, "🚦 Adaptive rate:     settled at %.2f RPS (%d rate limited responses)\n", result.SettledRate, result.ErrorKinds[methods.ErrorKindRateLimited])
+	}
+	if result.SLAConcurrency > 0 {
+		fmt.Fprintf(output, "🎚️  Within SLA:        %.1f workers at %.2f RPS (p95 target %dms)\n", result.SLAConcurrency, result.SLARequestsPerSec, slaLatency)
+	}
+	fmt.Fprintf(output, "📦 Transferred:       %s on the wire (%s decoded)\n", formatBytes(result.WireBytes), formatBytes(result.DecodedBytes))
+	if summary := connectionSummary(result); summary != "" {
+		fmt.Fprintf(output, "🔌 Connections:       %s\n", summary)
+	}
+	if summary := hotSetSummary(result, len(accounts)); summary != "" {
+		fmt.Fprintf(output, "🔥 Hot set:           %s\n", summary)
+	}
+	if summary := slotLagSummary(result); summary != "" {
+		fmt.Fprintf(output, "🧭 Slot lag:          %s\n", summary)
+	}
+	if warning := slotLagWarning(result); warning != "" {
+		fmt.Fprintf(output, "⚠️  Stale:             %s\n", warning)
+	}
+	if result.TotalRequests > 0 {
+		fmt.Fprintf(output, "📄 Avg payload:       %s per response\n", formatBytes(result.DecodedBytes/result.TotalRequests))
+	}
+	if result.CountedAccounts > 0 {
+		mode := "count only"
+		if streamDecode {
+			mode = "streamed"
+		}
+		fmt.Fprintf(output, "🧮 Accounts counted:  avg %d per response, %s per account (%s)\n",
+			result.CountedAccounts/result.SuccessCount, formatBytes(result.DecodedBytes/result.CountedAccounts), mode)
+	}
 
 	// Add latency statistics
-	if successCount > 0 {
-		avgLatency := totalLatency / time.Duration(successCount)
-		fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-		fmt.Println("⏱️  LATENCY STATISTICS")
-		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-		fmt.Printf("Min: %s\n", formatLatency(minLatency))
-		fmt.Printf("Max: %s\n", formatLatency(maxLatency))
-		fmt.Printf("Avg: %s\n", formatLatency(avgLatency))
+	if result.SuccessCount > 0 {
+		fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		fmt.Fprintln(output, "⏱️  LATENCY STATISTICS")
+		fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		fmt.Fprintf(output, "Min: %s\n", formatLatency(result.MinLatency))
+		fmt.Fprintf(output, "Max: %s\n", formatLatency(result.MaxLatency))
+		fmt.Fprintf(output, "Avg: %s\n", formatLatency(result.AvgLatency))
+		fmt.Fprintf(output, "P95: %s\n", formatLatency(result.P95Latency))
+		if label := slaComplianceLabel(result); label != "" {
+			fmt.Fprintf(output, "SLA: %s\n", label)
+		}
+	}
+	if warning := cacheHitWarning(result); warning != "" {
+		fmt.Fprintf(output, "\n⚠️  %s\n", warning)
 	}
 }
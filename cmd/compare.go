@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"rpc_test/methods"
+)
+
+// transportVariant is one client configuration in a side-by-side comparison
+type transportVariant struct {
+	Label string
+	Opts  methods.ClientOptions
+}
+
+// compareTransports runs the same method load once per variant and prints the delta between them
+func compareTransports(methodName string, title string, variants [2]transportVariant) {
+	fmt.Fprintf(output, "Starting %s comparison (%s vs %s) with %d concurrent requests for %d seconds per run\n",
+		methodName, variants[0].Label, variants[1].Label, concurrency, duration)
+	fmt.Fprintf(output, "RPC URL: %s\n", rpcURL)
+	fmt.Fprintf(output, "Proxy: %s\n", proxyLabel())
+	if !parameterlessMethods[methodName] {
+		fmt.Fprintf(output, "Number of accounts: %d\n", len(accounts))
+	}
+
+	var results [2]TestResult
+	var negotiated [2]string
+	for i, variant := range variants {
+		fmt.Fprintf(output, "\n🔄 Running with %s...\n", variant.Label)
+
+		// Build a fresh transport per variant so no connections are shared between runs
+		rpcTest := methods.NewRPCTestWithOptions(rpcURL, apiKey, variant.Opts)
+		applyProgramFilters(rpcTest)
+
+		result := runMethodLoad(methodName, rpcTest)
+		fmt.Fprintln(output)
+		printMethodSummary(result, rpcTest)
+
+		results[i] = result
+		negotiated[i] = rpcTest.NegotiatedProtocol()
+	}
+
+	a, b := results[0], results[1]
+
+	fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, title)
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(output, "%-18s %-14s %-14s %s\n", "", variants[0].Label, variants[1].Label, "Delta")
+	fmt.Fprintf(output, "%-18s %-14s %-14s\n", "Negotiated", negotiated[0], negotiated[1])
+	fmt.Fprintf(output, "%-18s %-14s %-14s %s\n", "Requests/second", formatDecimal(a.RequestsPerSec), formatDecimal(b.RequestsPerSec),
+		formatDelta(a.RequestsPerSec, b.RequestsPerSec))
+	fmt.Fprintf(output, "%-18s %-14s %-14s %s\n", "Success rate %", formatDecimal(a.SuccessRate), formatDecimal(b.SuccessRate),
+		formatDelta(a.SuccessRate, b.SuccessRate))
+	if a.SuccessCount > 0 && b.SuccessCount > 0 {
+		fmt.Fprintf(output, "%-18s %-14s %-14s %s\n", "Avg latency", formatLatency(a.AvgLatency), formatLatency(b.AvgLatency),
+			formatDelta(float64(a.AvgLatency), float64(b.AvgLatency)))
+	}
+	if a.TotalRequests > 0 && b.TotalRequests > 0 {
+		wireA := a.WireBytes / a.TotalRequests
+		wireB := b.WireBytes / b.TotalRequests
+		fmt.Fprintf(output, "%-18s %-14s %-14s %s\n", "Wire bytes/req", formatBytes(wireA), formatBytes(wireB),
+			formatDelta(float64(wireA), float64(wireB)))
+		decodedA := a.DecodedBytes / a.TotalRequests
+		decodedB := b.DecodedBytes / b.TotalRequests
+		fmt.Fprintf(output, "%-18s %-14s %-14s %s\n", "Decoded bytes/req", formatBytes(decodedA), formatBytes(decodedB),
+			formatDelta(float64(decodedA), float64(decodedB)))
+	}
+}
+
+// formatDelta returns the percentage change from before to after
+func formatDelta(before, after float64) string {
+	if before == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.*f%%", precision, (after-before)/before*100)
+}
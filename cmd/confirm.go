@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+)
+
+var (
+	// assumeYes answers the mainnet load prompt, required where there is no terminal to ask
+	assumeYes bool
+
+	// confirmThreshold is the load in worker-seconds above which a mainnet run asks for confirmation, 0 never asks
+	confirmThreshold int
+)
+
+// isMainnetURL reports whether targetURL looks like a Solana mainnet endpoint: its host names mainnet and not
+// devnet or testnet. Local hosts and unix sockets never are.
+func isMainnetURL(targetURL string) bool {
+	parsed, err := url.Parse(targetURL)
+	if err != nil || parsed.Scheme == "unix" {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if host == "localhost" {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil && (ip.IsLoopback() || ip.IsPrivate()) {
+		return false
+	}
+	if strings.Contains(host, "devnet") || strings.Contains(host, "testnet") {
+		return false
+	}
+	return strings.Contains(host, "mainnet")
+}
+
+// plannedDuration returns the seconds each method will run, the soak default included when it applies
+func plannedDuration(durationSet bool) int {
+	if soakMode && !durationSet {
+		return soakDefaultDuration
+	}
+	return duration
+}
+
+// suiteLoad returns the workers running at once and the seconds a suite of methods runs with workersPerMethod
+// each, concurrent methods adding up workers and sequential ones adding up time
+func suiteLoad(workersPerMethod, seconds, methodCount int) (int, int) {
+	if sequentialSuite {
+		return workersPerMethod, seconds * methodCount
+	}
+	return workersPerMethod * methodCount, seconds
+}
+
+// confirmLoad asks before running workers for seconds against a mainnet targetURL when the load is over
+// --confirm-threshold worker-seconds. --yes skips the prompt, and without a terminal to ask it is required.
+// The warning and prompt go to stderr, which --log-format json and --summary-only leave visible.
+func confirmLoad(targetURL string, workers, seconds int) {
+	load := workers * seconds
+	if confirmThreshold <= 0 || load <= confirmThreshold || !isMainnetURL(targetURL) {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "⚠️  %s is a mainnet endpoint and this run is %d workers for %ds (%d worker-seconds, over --confirm-threshold %d)\n",
+		redactURL(targetURL), workers, seconds, load, confirmThreshold)
+	if assumeYes {
+		logEvent("mainnet_load_confirmed", "url", redactURL(targetURL), "worker_seconds", load, "via", "yes")
+		return
+	}
+
+	stat, err := os.Stdin.Stat()
+	if err != nil || stat.Mode()&os.ModeCharDevice == 0 {
+		log.Fatalf("Refusing a %d worker-second run against mainnet without a terminal to confirm it, pass --yes to run anyway", load)
+	}
+	fmt.Fprint(os.Stderr, "   Continue? [y/N] ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		logEvent("mainnet_load_confirmed", "url", redactURL(targetURL), "worker_seconds", load, "via", "prompt")
+	default:
+		log.Fatalf("Aborted, lower --concurrency or --duration, or raise --confirm-threshold")
+	}
+}
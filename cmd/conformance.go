@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"rpc_test/methods"
+)
+
+// strictMode fails the run when any response does not have the reference RPC's shape
+var strictMode bool
+
+// conformance collects the --strict violations of the run, nil when --strict is not set
+var conformance *conformanceReport
+
+// ConformanceViolation counts the responses that broke the expected shape the same way
+type ConformanceViolation struct {
+	Method  string
+	Field   string
+	Count   int64
+	Example string // problem of the first response, e.g. the sizes of a data length mismatch
+}
+
+// conformanceReport tallies violations by method and field
+type conformanceReport struct {
+	mu         sync.Mutex
+	violations map[string]*ConformanceViolation
+}
+
+// startConformance starts collecting violations when --strict is set
+func startConformance() {
+	if !strictMode {
+		return
+	}
+	conformance = &conformanceReport{violations: make(map[string]*ConformanceViolation)}
+	fmt.Fprintln(output, "Strict mode: responses must match the reference RPC's shape")
+}
+
+// record counts err when it is a conformance violation; nil-safe
+func (c *conformanceReport) record(err error) {
+	violation := methods.AsConformanceError(err)
+	if c == nil || violation == nil {
+		return
+	}
+
+	key := violation.Method + " " + violation.Field
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.violations[key]
+	if !ok {
+		entry = &ConformanceViolation{Method: violation.Method, Field: violation.Field, Example: violation.Problem}
+		c.violations[key] = entry
+	}
+	entry.Count++
+}
+
+// finishConformance prints the conformance report and returns an error when any response broke the expected
+// shape, so call it after everything else of the run is written
+func finishConformance() error {
+	if conformance == nil {
+		return nil
+	}
+	report := conformance
+	conformance = nil
+
+	var violations []*ConformanceViolation
+	var total int64
+	for _, violation := range report.violations {
+		violations = append(violations, violation)
+		total += violation.Count
+	}
+	if len(violations) == 0 {
+		fmt.Fprintln(output, "\n✅ Strict mode: every response conformed")
+		return nil
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Method != violations[j].Method {
+			return violations[i].Method < violations[j].Method
+		}
+		return violations[i].Field < violations[j].Field
+	})
+
+	fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "🧾 CONFORMANCE REPORT")
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(output, "%-22s %-26s %-10s %s\n", "Method", "Field", "Responses", "Example")
+	for _, violation := range violations {
+		fmt.Fprintf(output, "%-22s %-26s %-10d %s\n", violation.Method, violation.Field, violation.Count, violation.Example)
+	}
+	return fmt.Errorf("strict mode: %d responses did not conform", total)
+}
+
+// exitOnNonConformance runs finishConformance and exits non-zero on its error, deferred first by commands
+// without an error return so their other deferred stops have run by then
+func exitOnNonConformance() {
+	if err := finishConformance(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"rpc_test/methods"
+
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// crossCheckPerf compares the node's self-reported performance with what the test observed
+var crossCheckPerf bool
+
+// capturePerfSample returns the node's most recent performance sample, or nil if it can't be fetched
+func capturePerfSample(label string) *rpc.GetRecentPerformanceSamplesResult {
+	rpcTest := methods.NewRPCTestWithOptions(rpcURL, apiKey, clientOptions())
+
+	samples, err := rpcTest.GetRecentPerformanceSamples(context.Background(), 1)
+	if err != nil {
+		fmt.Fprintf(output, "⚠️  Could not fetch performance samples %s the run: %v\n", label, err)
+		return nil
+	}
+	if len(samples) == 0 {
+		fmt.Fprintf(output, "⚠️  Node returned no performance samples %s the run\n", label)
+		return nil
+	}
+
+	return samples[0]
+}
+
+// printPerfCrossCheck prints the node's reported load before and after the run next to our measurements
+func printPerfCrossCheck(before, after *rpc.GetRecentPerformanceSamplesResult, results []TestResult) {
+	fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "🧭 NODE PERFORMANCE CROSS-CHECK")
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if before == nil && after == nil {
+		fmt.Fprintln(output, "No performance samples available from the node")
+		return
+	}
+
+	fmt.Fprintf(output, "%-20s %-16s %-16s\n", "", "Before", "After")
+	fmt.Fprintf(output, "%-20s %-16s %-16s\n", "Sample slot", perfField(before, func(s *rpc.GetRecentPerformanceSamplesResult) string {
+		return fmt.Sprintf("%d", s.Slot)
+	}), perfField(after, func(s *rpc.GetRecentPerformanceSamplesResult) string {
+		return fmt.Sprintf("%d", s.Slot)
+	}))
+	fmt.Fprintf(output, "%-20s %-16s %-16s\n", "Slots/sample", perfField(before, slotsPerSample), perfField(after, slotsPerSample))
+	fmt.Fprintf(output, "%-20s %-16s %-16s\n", "Transactions", perfField(before, transactionCount), perfField(after, transactionCount))
+	fmt.Fprintf(output, "%-20s %-16s %-16s\n", "Node TPS", perfField(before, nodeTPS), perfField(after, nodeTPS))
+
+	fmt.Fprintln(output, "\nMeasured by this run:")
+	for _, result := range results {
+		latency := "n/a"
+		if result.SuccessCount > 0 {
+			latency = formatLatency(result.AvgLatency)
+		}
+		fmt.Fprintf(output, "   %-22s %10.2f RPS | avg latency %s\n", result.MethodName, result.RequestsPerSec, latency)
+	}
+
+	// A node producing fewer slots than usual is struggling regardless of our load
+	if before != nil && after != nil && before.NumSlots > 0 && after.NumSlots < before.NumSlots*9/10 {
+		fmt.Fprintln(output, "\n⚠️  The node produced noticeably fewer slots per sample after the run,")
+		fmt.Fprintln(output, "   it may have been under pressure from real traffic or from this test")
+	}
+}
+
+// perfField formats a field of a sample, or "n/a" when the sample is missing
+func perfField(sample *rpc.GetRecentPerformanceSamplesResult, format func(*rpc.GetRecentPerformanceSamplesResult) string) string {
+	if sample == nil {
+		return "n/a"
+	}
+	return format(sample)
+}
+
+func slotsPerSample(s *rpc.GetRecentPerformanceSamplesResult) string {
+	return fmt.Sprintf("%d in %ds", s.NumSlots, s.SamplePeriodSecs)
+}
+
+func transactionCount(s *rpc.GetRecentPerformanceSamplesResult) string {
+	return fmt.Sprintf("%d", s.NumTransactions)
+}
+
+func nodeTPS(s *rpc.GetRecentPerformanceSamplesResult) string {
+	if s.SamplePeriodSecs == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.1f", float64(s.NumTransactions)/float64(s.SamplePeriodSecs))
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// dataDir is the --data-dir seeded accounts and other generated files are kept in
+var dataDir string
+
+var dataDirOnce sync.Once
+
+// defaultDataDir is rpc_test under $XDG_DATA_HOME when it is set, ./data otherwise
+func defaultDataDir() string {
+	if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+		return filepath.Join(xdg, "rpc_test")
+	}
+	return "./data"
+}
+
+// dataPath returns the path of name inside --data-dir, creating the directory on first use
+func dataPath(name string) string {
+	dataDirOnce.Do(func() {
+		if err := os.MkdirAll(dataDir, 0755); err != nil {
+			log.Fatalf("Failed to create data directory %s (set --data-dir to a writable directory): %v", dataDir, err)
+		}
+	})
+	return filepath.Join(dataDir, name)
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffThreshold    float64
+	failOnRegression bool
+)
+
+// diffMetric is one row of the diff table
+type diffMetric struct {
+	Name         string
+	Value        func(MethodResult) float64
+	Format       string
+	HigherBetter bool
+}
+
+var diffMetrics = []diffMetric{
+	{Name: "Requests/second", Value: func(r MethodResult) float64 { return r.RequestsPerSec }, Format: "%.2f", HigherBetter: true},
+	{Name: "Success rate %", Value: func(r MethodResult) float64 { return r.SuccessRate }, Format: "%.2f", HigherBetter: true},
+	{Name: "Avg latency ms", Value: func(r MethodResult) float64 { return r.AvgLatencyMs }, Format: "%.2f"},
+	{Name: "P95 latency ms", Value: func(r MethodResult) float64 { return r.P95LatencyMs }, Format: "%.2f"},
+}
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff <fileA> <fileB>",
+	Short: "Compare two saved --output result files",
+	Long: `Compare two results files saved with --output and print the per-method metric deltas.
+
+For every method the table shows RPS, success rate, average and p95 latency from both files 
+with the percentage change from fileA to fileB. Changes beyond --threshold are marked as 
+an improvement (🟢) or a regression (🔴), smaller changes are treated as noise (⚪). 
+Methods present in only one of the files are listed separately.
+
+Examples:
+  # Compare yesterday's run with today's
+  rpc_test diff results-yesterday.json results-today.json
+
+  # Exit non-zero when any metric regressed by more than 10%
+  rpc_test diff baseline.json current.json --threshold 10 --fail-on-regression`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		before, err := loadResults(args[0])
+		if err != nil {
+			log.Fatalf("Failed to load %s: %v", args[0], err)
+		}
+		after, err := loadResults(args[1])
+		if err != nil {
+			log.Fatalf("Failed to load %s: %v", args[1], err)
+		}
+
+		if regressions := printResultsDiff(before, after); regressions > 0 && failOnRegression {
+			fmt.Fprintf(output, "\n❌ %d regressions beyond %.1f%%\n", regressions, diffThreshold)
+			os.Exit(1)
+		}
+	},
+}
+
+// printResultsDiff prints the per-method delta table and returns the number of regressed metrics
+func printResultsDiff(before, after *ResultsFile) int {
+	afterByMethod := make(map[string]MethodResult)
+	for _, result := range after.Results {
+		afterByMethod[result.Method] = result
+	}
+	beforeByMethod := make(map[string]MethodResult)
+	for _, result := range before.Results {
+		beforeByMethod[result.Method] = result
+	}
+
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "📊 RESULTS DIFF")
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(output, "A: %s (%s, %s)\n", before.Timestamp.Format("2006-01-02 15:04:05"), before.Command, before.RPCURL)
+	fmt.Fprintf(output, "B: %s (%s, %s)\n", after.Timestamp.Format("2006-01-02 15:04:05"), after.Command, after.RPCURL)
+
+	regressions := 0
+	var onlyBefore, onlyAfter []string
+	for _, a := range before.Results {
+		b, ok := afterByMethod[a.Method]
+		if !ok {
+			onlyBefore = append(onlyBefore, a.Method)
+			continue
+		}
+
+		fmt.Fprintf(output, "\n📈 %s\n", a.Method)
+		fmt.Fprintf(output, "   %-16s %-12s %-12s %-10s\n", "", "A", "B", "Delta")
+		for _, metric := range diffMetrics {
+			valueA, valueB := metric.Value(a), metric.Value(b)
+			indicator := diffIndicator(valueA, valueB, metric.HigherBetter)
+			if indicator == "🔴" {
+				regressions++
+			}
+			fmt.Fprintf(output, "   %-16s %-12s %-12s %-10s %s\n", metric.Name,
+				fmt.Sprintf(metric.Format, valueA), fmt.Sprintf(metric.Format, valueB),
+				formatDelta(valueA, valueB), indicator)
+		}
+	}
+	for _, b := range after.Results {
+		if _, ok := beforeByMethod[b.Method]; !ok {
+			onlyAfter = append(onlyAfter, b.Method)
+		}
+	}
+
+	if len(onlyBefore) > 0 {
+		fmt.Fprintf(output, "\n⚠️  Only in A: %v\n", onlyBefore)
+	}
+	if len(onlyAfter) > 0 {
+		fmt.Fprintf(output, "⚠️  Only in B: %v\n", onlyAfter)
+	}
+
+	return regressions
+}
+
+// diffIndicator marks a change beyond --threshold as an improvement or a regression
+func diffIndicator(before, after float64, higherBetter bool) string {
+	if before == 0 {
+		return "⚪"
+	}
+
+	change := (after - before) / before * 100
+	if !higherBetter {
+		change = -change
+	}
+
+	switch {
+	case change > diffThreshold:
+		return "🟢"
+	case change < -diffThreshold:
+		return "🔴"
+	default:
+		return "⚪"
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().Float64Var(&diffThreshold, "threshold", 5, "Percentage change treated as noise rather than an improvement or regression")
+	diffCmd.Flags().BoolVar(&failOnRegression, "fail-on-regression", false, "Exit with status 1 when any metric regressed beyond --threshold")
+}
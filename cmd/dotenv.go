@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// dotenvFile is read from the working directory for --url and --api-key defaults
+const dotenvFile = ".env"
+
+// Variables that supply --url and --api-key when the flags are not given
+const (
+	envTargetURL = "TARGET_RPC_URL"
+	envAPIKey    = "API_KEY"
+)
+
+// applyEnvDefaults sets --url and --api-key from the environment, or from .env when the environment doesn't
+// have them, unless they were given on the command line. A missing .env is not an error.
+func applyEnvDefaults() {
+	dotenv, err := readDotenv(dotenvFile)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", dotenvFile, err)
+	}
+
+	if !RootCmd.PersistentFlags().Changed("url") {
+		if value, source := envValue(envTargetURL, dotenv); value != "" {
+			rpcURL = value
+			logEvent("env_default", "flag", "url", "source", source)
+		}
+	}
+	// --api-key is only a flag of runall, the other commands take the key from here alone
+	if !runallCmd.Flags().Changed("api-key") {
+		if value, source := envValue(envAPIKey, dotenv); value != "" {
+			apiKey = value
+			logEvent("env_default", "flag", "api-key", "source", source)
+		}
+	}
+}
+
+// envValue returns the value of name from the environment, else from dotenv, with where it came from
+func envValue(name string, dotenv map[string]string) (value, source string) {
+	if value, ok := os.LookupEnv(name); ok {
+		return strings.TrimSpace(value), "environment"
+	}
+	return dotenv[name], dotenvFile
+}
+
+// readDotenv parses KEY=VALUE lines of a .env file, skipping blank lines and # comments and accepting an
+// export prefix and quoted values. A missing file yields no values.
+func readDotenv(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		} else if comment := strings.Index(value, " #"); comment >= 0 {
+			value = strings.TrimSpace(value[:comment])
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"rpc_test/methods"
+)
+
+// RPCErrorCount tallies the failures the RPC answered with one JSON-RPC error code, e.g. -32005 when the
+// node is behind or -32602 when the params are wrong
+type RPCErrorCount struct {
+	Code    int    `json:"code"`
+	Count   int64  `json:"count"`
+	Message string `json:"message"` // first message seen with the code
+}
+
+// tallyErrorCode counts err under its JSON-RPC error code, errors without one are left to the error kinds
+func tallyErrorCode(codes map[int]*RPCErrorCount, err error) {
+	code, message, ok := methods.RPCErrorCode(err)
+	if !ok {
+		return
+	}
+	if codes[code] == nil {
+		codes[code] = &RPCErrorCount{Code: code, Message: message}
+	}
+	codes[code].Count++
+}
+
+// sortedErrorCodes returns the tallied codes, most frequent first
+func sortedErrorCodes(codes map[int]*RPCErrorCount) []RPCErrorCount {
+	sorted := make([]RPCErrorCount, 0, len(codes))
+	for _, count := range codes {
+		sorted = append(sorted, *count)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Code < sorted[j].Code
+	})
+	return sorted
+}
+
+// printErrorCodes prints a code → count → message table of the JSON-RPC errors, nothing when there were none
+func printErrorCodes(codes map[int]*RPCErrorCount, indent string) {
+	if len(codes) == 0 {
+		return
+	}
+	fmt.Fprintf(output, "%sJSON-RPC errors:\n", indent)
+	fmt.Fprintf(output, "%s   %-8s %-8s %s\n", indent, "Code", "Count", "Message")
+	for _, count := range sortedErrorCodes(codes) {
+		fmt.Fprintf(output, "%s   %-8d %-8d %s\n", indent, count.Code, count.Count, count.Message)
+	}
+}
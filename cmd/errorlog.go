@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"rpc_test/methods"
+)
+
+// Error log queueing and flushing
+const (
+	errorLogQueueSize     = 4096
+	errorLogFlushInterval = time.Second
+)
+
+var (
+	// errorLogPath appends every failed request to this file when set
+	errorLogPath string
+	// errorLogMaxMB rotates the error log once it grows past this many megabytes
+	errorLogMaxMB int
+	// maxPayloadLog caps the bytes of each logged message, 0 to log messages whole
+	maxPayloadLog int
+)
+
+// failureLogger writes failed requests to --error-log, nil when the flag is not set
+var failureLogger *failureLog
+
+// failureLogEntry is one failed request, written as a JSON line
+type failureLogEntry struct {
+	Time      time.Time `json:"time"`
+	Method    string    `json:"method"`
+	Accounts  []string  `json:"accounts,omitempty"`
+	Kind      string    `json:"kind"`
+	Code      int       `json:"code,omitempty"`
+	Message   string    `json:"message"`
+	FullBytes int       `json:"message_bytes,omitempty"` // size of the message before --max-payload-log truncated it
+	LatencyMs float64   `json:"latency_ms"`
+}
+
+// failureLog appends failed requests to a size capped file from its own goroutine, so workers never
+// wait on the disk
+type failureLog struct {
+	path     string
+	maxBytes int64
+	entries  chan failureLogEntry
+	done     chan struct{}
+	dropped  atomic.Int64
+	written  int64
+
+	file *os.File
+	buf  *bufio.Writer
+	size int64
+}
+
+// startErrorLog opens --error-log for appending when it is set
+func startErrorLog() error {
+	if errorLogPath == "" {
+		return nil
+	}
+	if errorLogMaxMB < 1 {
+		return fmt.Errorf("invalid --error-log-max-size %d, expected at least 1 (MB)", errorLogMaxMB)
+	}
+	if maxPayloadLog < 0 {
+		return fmt.Errorf("invalid --max-payload-log %d, expected 0 (no cap) or more bytes", maxPayloadLog)
+	}
+
+	failureLogger = &failureLog{
+		path:     errorLogPath,
+		maxBytes: int64(errorLogMaxMB) << 20,
+		entries:  make(chan failureLogEntry, errorLogQueueSize),
+		done:     make(chan struct{}),
+	}
+	if err := failureLogger.open(); err != nil {
+		failureLogger = nil
+		return fmt.Errorf("failed to open error log: %v", err)
+	}
+	go failureLogger.run()
+
+	fmt.Fprintf(output, "Logging failed requests to: %s\n", errorLogPath)
+	return nil
+}
+
+// stopErrorLog writes out the queued entries and closes the error log
+func stopErrorLog() {
+	if failureLogger == nil {
+		return
+	}
+
+	close(failureLogger.entries)
+	<-failureLogger.done
+
+	fmt.Fprintf(output, "📝 Error log: %d failed requests written to %s\n", failureLogger.written, failureLogger.path)
+	if dropped := failureLogger.dropped.Load(); dropped > 0 {
+		fmt.Fprintf(output, "⚠️  Dropped %d error log entries because the writer fell behind\n", dropped)
+	}
+	failureLogger = nil
+}
+
+// record queues a failed request without blocking the worker, it is a no-op on a nil writer or a nil err
+func (w *failureLog) record(method string, args []string, latency time.Duration, err error) {
+	if w == nil || err == nil {
+		return
+	}
+
+	entry := failureLogEntry{
+		Time:      time.Now().UTC(),
+		Method:    method,
+		Accounts:  args,
+		Kind:      methods.ClassifyError(err),
+		LatencyMs: float64(latency.Microseconds()) / 1000,
+	}
+	entry.Message, entry.FullBytes = truncatePayload(err.Error())
+	if code, _, ok := methods.RPCErrorCode(err); ok {
+		entry.Code = code
+	}
+
+	select {
+	case w.entries <- entry:
+	default:
+		w.dropped.Add(1)
+	}
+}
+
+// truncatePayload cuts payload to --max-payload-log bytes on a character boundary and notes the cut in it,
+// returning the original size when it was cut and 0 when it fit
+func truncatePayload(payload string) (string, int) {
+	if maxPayloadLog <= 0 || len(payload) <= maxPayloadLog {
+		return payload, 0
+	}
+	cut := maxPayloadLog
+	for cut > 0 && !utf8.RuneStart(payload[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... [truncated to %d of %d bytes]", payload[:cut], cut, len(payload)), len(payload)
+}
+
+// run writes queued entries until the queue is closed, flushing the buffer every errorLogFlushInterval
+func (w *failureLog) run() {
+	defer close(w.done)
+	defer w.close()
+
+	ticker := time.NewTicker(errorLogFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case entry, ok := <-w.entries:
+			if !ok {
+				return
+			}
+			w.write(entry)
+		case <-ticker.C:
+			if err := w.buf.Flush(); err != nil {
+				fmt.Fprintf(output, "⚠️  Failed to flush error log: %v\n", err)
+			}
+		}
+	}
+}
+
+// write appends one entry, rotating the file first when the entry would push it past the cap
+func (w *failureLog) write(entry failureLogEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	if w.size > 0 && w.size+int64(len(line)) > w.maxBytes {
+		if err := w.rotate(); err != nil {
+			fmt.Fprintf(output, "⚠️  Failed to rotate error log: %v\n", err)
+		}
+		if w.file == nil {
+			w.dropped.Add(1)
+			return
+		}
+	}
+
+	n, err := w.buf.Write(line)
+	w.size += int64(n)
+	if err != nil {
+		w.dropped.Add(1)
+		return
+	}
+	w.written++
+}
+
+// open opens the error log for appending, continuing from its current size
+func (w *failureLog) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file, w.buf, w.size = file, bufio.NewWriter(file), info.Size()
+	return nil
+}
+
+// rotate moves the full log to <path>.1, replacing the previous one, and starts a new file,
+// so the log never takes more than twice the cap on disk
+func (w *failureLog) rotate() error {
+	if err := w.close(); err != nil {
+		return err
+	}
+
+	// Keep appending to the full file rather than losing entries when it can't be moved
+	renameErr := os.Rename(w.path, w.path+".1")
+	if err := w.open(); err != nil {
+		return err
+	}
+	return renameErr
+}
+
+// close flushes the buffer and closes the file
+func (w *failureLog) close() error {
+	if w.file == nil {
+		return nil
+	}
+	flushErr := w.buf.Flush()
+	closeErr := w.file.Close()
+	w.file = nil
+	if flushErr != nil {
+		return flushErr
+	}
+	return closeErr
+}
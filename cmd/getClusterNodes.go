@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// getClusterNodesCmd represents the getClusterNodes command
+var getClusterNodesCmd = &cobra.Command{
+	Use:   "getClusterNodes",
+	Short: "Run performance tests for getClusterNodes RPC method",
+	Long: `Run stress tests against Solana RPC endpoints using the getClusterNodes method.
+
+This method returns gossip, TPU and RPC addresses for every node in the cluster. Like 
+getVoteAccounts it is a staple of validator-monitoring tools and returns a large list 
+that is served from a different code path than account reads. No accounts are needed.
+
+Features:
+• No Inputs Required: Benchmarks the cluster node listing directly
+• Payload Size: Reports transferred bytes per response alongside latency
+• Real-time Progress: Visual progress bars with completion percentage and live statistics
+• Comprehensive Metrics: Success rate, RPS, and latency statistics with dynamic unit formatting
+
+Examples:
+  # Poll cluster nodes with 5 concurrent requests
+  rpc_test getClusterNodes --url https://your-target-rpc.com --concurrency 5 --duration 30`,
+	Run: func(cmd *cobra.Command, args []string) {
+		RunMethodTest("getClusterNodes")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(getClusterNodesCmd)
+}
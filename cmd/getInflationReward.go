@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var inflationEpoch uint64
+
+// getInflationRewardCmd represents the getInflationReward command
+var getInflationRewardCmd = &cobra.Command{
+	Use:   "getInflationReward",
+	Short: "Run performance tests for getInflationReward RPC method",
+	Long: `Run stress tests against Solana RPC endpoints using the getInflationReward method.
+
+This method returns the staking rewards credited to a list of addresses for an epoch. 
+It is one of the heavier staking reads because the node has to scan the epoch's reward 
+block. Addresses are batched into groups of 5-14 per request, just like getMultipleAccounts.
+
+Features:
+• Automatic Batching: Groups 5-14 stake or vote addresses per request
+• Epoch Selection: Query a specific epoch with --epoch (default: last completed epoch)
+• Real-time Progress: Visual progress bars with completion percentage and live statistics
+• Comprehensive Metrics: Success rate, RPS, and latency statistics with dynamic unit formatting
+
+Examples:
+  # Test with stake accounts from a file
+  rpc_test getInflationReward --account-file ./stake_accounts.txt --concurrency 5 --duration 30
+
+  # Test rewards of a specific epoch
+  rpc_test getInflationReward --account-file ./stake_accounts.txt --epoch 600 --concurrency 5`,
+	Run: func(cmd *cobra.Command, args []string) {
+		RunMethodTest("getInflationReward")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(getInflationRewardCmd)
+
+	getInflationRewardCmd.Flags().Uint64Var(&inflationEpoch, "epoch", 0, "Epoch to query rewards for (0 for the last completed epoch)")
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// getLargestAccountsCmd represents the getLargestAccounts command
+var getLargestAccountsCmd = &cobra.Command{
+	Use:   "getLargestAccounts",
+	Short: "Run performance tests for getLargestAccounts RPC method",
+	Long: `Run stress tests against Solana RPC endpoints using the getLargestAccounts method.
+
+This method returns the 20 largest accounts by lamport balance. Computing it is notably 
+heavy, so many providers cache the result, which makes it a good probe of an endpoint's 
+caching behavior on the analytics/explorer read path. No accounts are needed.
+
+Features:
+• No Inputs Required: Benchmarks the largest-accounts query directly
+• Cache Probe: Very low latency here usually means the result is served from a cache
+• Payload Size: Reports transferred bytes per response alongside latency
+• Comprehensive Metrics: Success rate, RPS, and latency statistics with dynamic unit formatting
+
+Examples:
+  # Probe getLargestAccounts with 2 concurrent requests
+  rpc_test getLargestAccounts --url https://your-target-rpc.com --concurrency 2 --duration 30`,
+	Run: func(cmd *cobra.Command, args []string) {
+		RunMethodTest("getLargestAccounts")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(getLargestAccountsCmd)
+}
@@ -10,12 +10,12 @@ var getMultipleAccountsCmd = &cobra.Command{
 	Short: "Run performance tests for getMultipleAccounts RPC method",
 	Long: `Run stress tests against Solana RPC endpoints using the getMultipleAccounts method.
 
-This method automatically batches accounts from your provided list into groups of 5-15 accounts 
+This method automatically batches accounts from your provided list into groups of 5-14 accounts 
 per request (randomized) to simulate realistic batch retrieval patterns. Each worker thread 
 will rotate through your account list and create different batch combinations.
 
 Features:
-• Automatic Batching: Groups 5-15 accounts per request (randomized for variety)
+• Automatic Batching: Groups 5-14 accounts per request (randomized for variety)
 • Account Rotation: Cycles through provided accounts for load distribution
 • Real-time Progress: Visual progress bars with completion percentage and live statistics
 • Comprehensive Metrics: Success rate, RPS, and latency statistics with dynamic unit formatting
@@ -1,16 +1,28 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"strings"
 
+	"rpc_test/methods"
+
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
 	"github.com/spf13/cobra"
 )
 
 var (
 	programs     []string
 	programsFile string
+
+	// countOnly enumerates accounts with a zero length dataSlice, measuring enumeration without data transfer
+	countOnly bool
+
+	// streamDecode counts accounts as the response streams in instead of decoding it whole, bounding memory
+	streamDecode bool
 )
 
 // getProgramAccountsCmd represents the getProgramAccounts command
@@ -40,7 +52,16 @@ Examples:
   rpc_test getProgramAccounts --program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA --program 2wT8Yq49kHgDzXuPxZSaeLaH1qbmGXtEyPy64bL7aD3c --concurrency 10 --duration 45
 
   # Test with programs from a file (recommended for multiple programs)
-  rpc_test getProgramAccounts --program-file ./programs.txt --concurrency 20 --duration 60 --limit 10`,
+  rpc_test getProgramAccounts --program-file ./programs.txt --concurrency 20 --duration 60 --limit 10
+
+  # Only count the accounts, fetching no account data
+  rpc_test getProgramAccounts --program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA --count-only
+
+  # Time enumerating every account in pages of 100
+  rpc_test getProgramAccounts --program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA --paginate keys --page-size 100
+
+  # Check that a second endpoint returns the same accounts
+  rpc_test getProgramAccounts --program-file ./programs.txt --url https://provider-a.com --verify-against https://provider-b.com`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Load programs from file if provided
 		if programsFile != "" {
@@ -61,16 +82,37 @@ Examples:
 		if len(programs) == 0 {
 			log.Fatalf("No programs provided. Use --program or --program-file to specify programs")
 		}
+		if streamDecode && strictMode {
+			log.Fatalf("--stream-decode does not keep the accounts --strict checks, use one or the other")
+		}
 
-		// Apply limit if specified
-		totalPrograms := len(programs)
-		if limit > 0 && limit < totalPrograms {
-			programs = programs[:limit]
-			log.Printf("Limiting to %d programs out of %d available", limit, totalPrograms)
+		// Apply the offset and limit if specified, the load test does it again on accounts as a no-op
+		window, err := accountWindow(programs, accountOffset, limit)
+		if err != nil {
+			log.Fatalf("Invalid --account-offset: %v", err)
 		}
+		if len(window) < len(programs) {
+			log.Printf("Using %d programs out of %d available", len(window), len(programs))
+		}
+		programs = window
 
-		// Use programs as accounts for the underlying test runner
+		if err := resolveProgramFilters(nil); err != nil {
+			log.Fatal(err)
+		}
+
+		if pageStrategy != "" {
+			runPagination()
+			return
+		}
+		if len(verifyURLs) > 0 {
+			runVerification()
+			return
+		}
+
+		// Use programs as accounts for the underlying test runner, already windowed
 		accounts = programs
+		accountList = nil
+		accountOffset = 0
 
 		RunMethodTest("getProgramAccounts")
 	},
@@ -82,6 +124,18 @@ func init() {
 	// Add program-specific flags
 	getProgramAccountsCmd.Flags().StringArrayVarP(&programs, "program", "p", []string{}, "Program addresses to use in tests (can be specified multiple times)")
 	getProgramAccountsCmd.Flags().StringVarP(&programsFile, "program-file", "f", "", "File containing program addresses (one per line)")
+	getProgramAccountsCmd.Flags().StringArrayVar(&programDiscriminators, "programs-discriminator", []string{}, "PROGRAM=VALUE restricting the program's accounts to those starting with this discriminator (can be specified multiple times)")
+	getProgramAccountsCmd.Flags().IntVar(&discriminatorSize, "discriminator-size", defaultDiscriminatorSize, "Bytes of the --programs-discriminator values: 1, 2, 4 or 8 (anchor)")
+	getProgramAccountsCmd.Flags().BoolVar(&countOnly, "count-only", false, "Fetch accounts with a zero length dataSlice and only count them, isolating enumeration from data transfer")
+	getProgramAccountsCmd.Flags().BoolVar(&streamDecode, "stream-decode", false, "Count the accounts as each response streams in instead of decoding it whole, keeping memory flat on large programs")
+
+	getProgramAccountsCmd.Flags().StringVar(&pageStrategy, "paginate", "", "Time a paged enumeration of each program instead of a load test: memcmp, datasize or keys")
+	getProgramAccountsCmd.Flags().IntVar(&pageSize, "page-size", 100, "Accounts per getMultipleAccounts page of --paginate keys (max 100)")
+	getProgramAccountsCmd.Flags().Uint64Var(&pageOffset, "page-offset", 0, "Offset of the byte whose 256 values form the pages of --paginate memcmp")
+	getProgramAccountsCmd.Flags().StringSliceVar(&pageDataSizes, "page-data-sizes", []string{}, "Account sizes forming the pages of --paginate datasize, comma separated")
+
+	getProgramAccountsCmd.Flags().StringArrayVar(&verifyURLs, "verify-against", []string{}, "Instead of a load test, check that this endpoint returns the same accounts as --url for every program (can be specified multiple times)")
+	getProgramAccountsCmd.Flags().BoolVar(&verifyData, "verify-data", false, "Hash the account data too with --verify-against, not only the pubkeys")
 
 	// Override the account-file flag to avoid confusion
 	getProgramAccountsCmd.Flags().StringVarP(&accountsFile, "account-file", "", "", "")
@@ -90,4 +144,21 @@ func init() {
 	// Override the account flag to avoid confusion
 	getProgramAccountsCmd.Flags().StringArrayVarP(&accounts, "account", "", []string{}, "")
 	getProgramAccountsCmd.Flags().MarkHidden("account")
+	getProgramAccountsCmd.Flags().Var(&accountList, "accounts", "")
+	getProgramAccountsCmd.Flags().MarkHidden("accounts")
+}
+
+// probeCountOnly checks that the RPC accepts a zero length dataSlice before a --count-only run
+func probeCountOnly(rpcTest *methods.RPCTest) {
+	count, err := rpcTest.CountProgramAccounts(context.Background(), accounts[0])
+
+	var rpcErr *jsonrpc.RPCError
+	if errors.As(err, &rpcErr) {
+		log.Fatalf("--count-only needs a zero length dataSlice, which the RPC rejected: code %d: %s", rpcErr.Code, rpcErr.Message)
+	}
+	if err != nil {
+		fmt.Fprintf(output, "⚠️  Probe request failed, running anyway: %v\n", err)
+		return
+	}
+	fmt.Fprintf(output, "Count only: %s owns %d accounts\n", accounts[0], count)
 }
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// getStakeActivationCmd represents the getStakeActivation command
+var getStakeActivationCmd = &cobra.Command{
+	Use:   "getStakeActivation",
+	Short: "Run performance tests for getStakeActivation RPC method",
+	Long: `Run stress tests against Solana RPC endpoints using the getStakeActivation method.
+
+This method returns the activation state (active, inactive, activating or deactivating) 
+of a stake account. It is compute-intensive on the node and a staple of staking dashboards. 
+Provide stake account addresses; each worker thread rotates through the list.
+
+Features:
+• Stake Account Rotation: Cycles through provided stake accounts for load distribution
+• Real-time Progress: Visual progress bars with completion percentage and live statistics
+• Comprehensive Metrics: Success rate, RPS, and latency statistics with dynamic unit formatting
+
+Examples:
+  # Test with stake accounts from a file
+  rpc_test getStakeActivation --account-file ./stake_accounts.txt --concurrency 5 --duration 30
+
+  # Test with a single stake account
+  rpc_test getStakeActivation --account STAKE_ACCOUNT_ADDRESS --concurrency 2 --duration 15`,
+	Run: func(cmd *cobra.Command, args []string) {
+		RunMethodTest("getStakeActivation")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(getStakeActivationCmd)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// getSupplyCmd represents the getSupply command
+var getSupplyCmd = &cobra.Command{
+	Use:   "getSupply",
+	Short: "Run performance tests for getSupply RPC method",
+	Long: `Run stress tests against Solana RPC endpoints using the getSupply method.
+
+This method returns the total, circulating and non-circulating SOL supply along with the 
+non-circulating accounts. It is a staple of chain-analytics and explorer backends. 
+No accounts are needed.
+
+Features:
+• No Inputs Required: Benchmarks the supply query directly
+• Payload Size: Reports transferred bytes per response alongside latency
+• Real-time Progress: Visual progress bars with completion percentage and live statistics
+• Comprehensive Metrics: Success rate, RPS, and latency statistics with dynamic unit formatting
+
+Examples:
+  # Benchmark getSupply with 5 concurrent requests
+  rpc_test getSupply --url https://your-target-rpc.com --concurrency 5 --duration 30`,
+	Run: func(cmd *cobra.Command, args []string) {
+		RunMethodTest("getSupply")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(getSupplyCmd)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// getVoteAccountsCmd represents the getVoteAccounts command
+var getVoteAccountsCmd = &cobra.Command{
+	Use:   "getVoteAccounts",
+	Short: "Run performance tests for getVoteAccounts RPC method",
+	Long: `Run stress tests against Solana RPC endpoints using the getVoteAccounts method.
+
+This method returns every current and delinquent vote account in the cluster. It is polled 
+constantly by validator-monitoring and staking tools, returns a large-ish list, and exercises 
+a different server code path than account reads. No accounts are needed.
+
+Features:
+• No Inputs Required: Benchmarks the cluster-wide vote account listing directly
+• Payload Size: Reports transferred bytes per response alongside latency
+• Real-time Progress: Visual progress bars with completion percentage and live statistics
+• Comprehensive Metrics: Success rate, RPS, and latency statistics with dynamic unit formatting
+
+Examples:
+  # Poll vote accounts with 5 concurrent requests
+  rpc_test getVoteAccounts --url https://your-target-rpc.com --concurrency 5 --duration 30`,
+	Run: func(cmd *cobra.Command, args []string) {
+		RunMethodTest("getVoteAccounts")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(getVoteAccountsCmd)
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"io"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+
+	"rpc_test/methods"
+)
+
+// Supported values for --log-format
+const (
+	logFormatPretty = "pretty"
+	logFormatJSON   = "json"
+)
+
+var logFormat string
+
+// eventLogger emits structured lifecycle events, nil in the default pretty mode
+var eventLogger *slog.Logger
+
+// output receives the decorated console output: stdout, unless --log-format json or --summary-only keep stdout
+// for their machine-readable output
+var output io.Writer = os.Stdout
+
+// setOutput sends the decorated output, this package's and the seeding messages of methods, to w
+func setOutput(w io.Writer) {
+	output = w
+	methods.SetOutput(w)
+}
+
+// errorLogWriter turns log.Printf/log.Fatalf output into error events
+type errorLogWriter struct{}
+
+func (errorLogWriter) Write(p []byte) (int, error) {
+	eventLogger.Error(strings.TrimSpace(string(p)), "event", "error")
+	return len(p), nil
+}
+
+// setupLogging switches to JSON lines on stdout when --log-format json is set
+func setupLogging() {
+	switch logFormat {
+	case logFormatPretty:
+		return
+	case logFormatJSON:
+	default:
+		log.Fatalf("Invalid --log-format %q (expected %s or %s)", logFormat, logFormatPretty, logFormatJSON)
+	}
+
+	eventLogger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	log.SetFlags(0)
+	log.SetOutput(errorLogWriter{})
+
+	// Discard the decorated output rather than interleave it with the events
+	setOutput(io.Discard)
+}
+
+// logEvent emits a lifecycle event in JSON mode and is a no-op otherwise
+func logEvent(event string, args ...any) {
+	if eventLogger == nil {
+		return
+	}
+	eventLogger.Info(event, append([]any{"event", event}, args...)...)
+}
+
+// logWarn emits a non-fatal problem in JSON mode and is a no-op otherwise
+func logWarn(event string, err error, args ...any) {
+	if eventLogger == nil {
+		return
+	}
+	eventLogger.Warn(event, append([]any{"event", event, "error", err.Error()}, args...)...)
+}
+
+// logMethodFinished emits the metrics of a finished method test
+func logMethodFinished(result TestResult) {
+	transportFailures, rpcFailures := splitFailures(result.ErrorKinds)
+	logEvent("method_finished",
+		"method", result.MethodName,
+		"duration_s", result.Duration.Seconds(),
+		"total_requests", result.TotalRequests,
+		"success_count", result.SuccessCount,
+		"empty_count", result.EmptyCount,
+		"partial_response_count", result.PartialResponseCount,
+		"counted_accounts", result.CountedAccounts,
+		"failure_count", result.FailureCount,
+		"transport_failures", transportFailures,
+		"rpc_failures", rpcFailures,
+		"decode_failures", result.ErrorKinds[methods.ErrorKindDecode],
+		"conformance_failures", result.ErrorKinds[methods.ErrorKindConformance],
+		"null_account_failures", result.ErrorKinds[methods.ErrorKindNullAccounts],
+		"sla_compliant", result.SLACompliant,
+		"avg_slot_lag", result.AvgSlotLag,
+		"stale_responses", result.StaleResponses,
+		"requests_per_sec", result.RequestsPerSec,
+		"success_rate", result.SuccessRate,
+		"min_latency_ms", float64(result.MinLatency.Microseconds())/1000,
+		"max_latency_ms", float64(result.MaxLatency.Microseconds())/1000,
+		"avg_latency_ms", float64(result.AvgLatency.Microseconds())/1000,
+		"p95_latency_ms", float64(result.P95Latency.Microseconds())/1000,
+		"wire_bytes", result.WireBytes,
+		"decoded_bytes", result.DecodedBytes,
+		"skipped_by_breaker", result.SkippedByBreaker,
+		"error", result.Error,
+	)
+}
+
+// logConfigLoaded emits the effective test configuration
+func logConfigLoaded(command string) {
+	logEvent("config_loaded",
+		"command", command,
+		"url", redactURL(rpcURL),
+		"concurrency", concurrency,
+		"duration_s", duration,
+		"protocol", protocol,
+		"compression", compression,
+		"proxy", proxyLabel(),
+	)
+}
+
+// redactURL strips credentials and query parameters such as API keys from a URL for logging
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	u.RawQuery = ""
+	return u.String()
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"rpc_test/methods"
+)
+
+// accountEncoding is the data encoding account reads request, solana-go's default
+const accountEncoding = "base64"
+
+// accountCommitment is the commitment account reads are served at: they send none, so nodes use finalized
+const accountCommitment = "finalized"
+
+// runSeed seeds the per-worker account pickers and retry jitter, once per invocation so every method in a run shares it
+var runSeed = time.Now().UnixNano()
+
+// RunMetadata describes how a run was produced, so every summary and results file is self-describing.
+// It never carries API keys: the target URL is redacted and the key is not recorded.
+type RunMetadata struct {
+	Version      string    `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+	Command      string    `json:"command"`
+	RPCURL       string    `json:"rpc_url"`
+	Commitment   string    `json:"commitment"`
+	Encoding     string    `json:"encoding"`
+	Concurrency  int       `json:"concurrency"`
+	DurationSecs int       `json:"duration_s"`
+	Methods      []string  `json:"methods"`
+	AccountCount int       `json:"account_count"`
+	Seed         int64     `json:"seed"`
+	Slot         uint64    `json:"slot,omitempty"`
+	Mode         string    `json:"mode,omitempty"` // concurrent or sequential, for the method suites of runall and benchmark
+	CooldownSecs int       `json:"cooldown_s,omitempty"`
+	CooldownPing bool      `json:"cooldown_ping,omitempty"`
+}
+
+// newRunMetadata captures the current flags for a run of methodNames over accountCount accounts
+func newRunMetadata(command string, methodNames []string, accountCount int) RunMetadata {
+	// Only a getFeeForMessage run takes --commitment, every other run reads at the account commitment
+	commitment := accountCommitment
+	if len(methodNames) == 1 && methodNames[0] == "getFeeForMessage" {
+		commitment = feeCommitment
+		if commitment == "" {
+			commitment = "default"
+		}
+	}
+
+	return RunMetadata{
+		Version:      methods.Version,
+		Timestamp:    time.Now().UTC(),
+		Command:      command,
+		RPCURL:       redactURL(rpcURL),
+		Commitment:   commitment,
+		Encoding:     accountEncoding,
+		Concurrency:  concurrency,
+		DurationSecs: duration,
+		Methods:      methodNames,
+		AccountCount: accountCount,
+		Seed:         runSeed,
+		Slot:         pinnedSlot,
+	}
+}
+
+// printRunMetadata prints the metadata header of a terminal summary
+func printRunMetadata(meta RunMetadata) {
+	fmt.Fprintf(output, "🏷️  rpc_test %s (%s) at %s\n", meta.Version, meta.Command, meta.Timestamp.Format(time.RFC3339))
+	fmt.Fprintf(output, "   Target:            %s\n", meta.RPCURL)
+	fmt.Fprintf(output, "   Commitment:        %s, Encoding: %s\n", meta.Commitment, meta.Encoding)
+	fmt.Fprintf(output, "   Concurrency:       %d, Duration: %ds per method\n", meta.Concurrency, meta.DurationSecs)
+	fmt.Fprintf(output, "   Methods:           %s\n", strings.Join(meta.Methods, ", "))
+	if meta.Mode != "" {
+		fmt.Fprintf(output, "   Mode:              %s\n", meta.Mode)
+	}
+	if meta.CooldownSecs > 0 {
+		activity := "idle"
+		if meta.CooldownPing {
+			activity = "getSlot pings"
+		}
+		fmt.Fprintf(output, "   Cooldown:          %ds between methods (%s)\n", meta.CooldownSecs, activity)
+	}
+	fmt.Fprintf(output, "   Accounts:          %d, Seed: %d\n", meta.AccountCount, meta.Seed)
+	if meta.Slot > 0 {
+		fmt.Fprintf(output, "   Pinned slot:       %d\n", meta.Slot)
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"rpc_test/methods"
+
+	"github.com/spf13/cobra"
+)
+
+// methodsJSON prints the registry as JSON instead of a table
+var methodsJSON bool
+
+// methodsCmd represents the methods command
+var methodsCmd = &cobra.Command{
+	Use:   "methods",
+	Short: "List the supported RPC methods",
+	Long: `List every RPC method the tool can benchmark, with the arguments each request takes and
+whether it is part of the suite run by runall, benchmark and the server.
+
+The list comes from the same registry the commands dispatch through, so it is always
+what this build supports.
+
+Examples:
+  # Print the methods as a table
+  rpc_test methods
+
+  # Print the methods as JSON for scripts
+  rpc_test methods --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if methodsJSON {
+			data, err := json.MarshalIndent(methods.Registry, "", "  ")
+			if err != nil {
+				log.Fatalf("Failed to encode methods: %v", err)
+			}
+			fmt.Fprintln(output, string(data))
+			return
+		}
+
+		fmt.Fprintf(output, "📋 %d supported methods:\n\n", len(methods.Registry))
+		fmt.Fprintf(output, "%-22s %-9s %-7s %s\n", "Method", "Args", "Suite", "Description")
+		for _, spec := range methods.Registry {
+			suite := ""
+			if spec.Suite {
+				suite = "✓"
+			}
+			fmt.Fprintf(output, "%-22s %-9s %-7s %s\n", spec.Name, spec.Args, suite, spec.Description)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(methodsCmd)
+
+	methodsCmd.Flags().BoolVar(&methodsJSON, "json", false, "Print the methods as JSON")
+}
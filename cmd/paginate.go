@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"rpc_test/methods"
+)
+
+var (
+	// pageStrategy enumerates the programs page by page instead of running a load test, empty when off
+	pageStrategy  string
+	pageSize      int
+	pageOffset    uint64
+	pageDataSizes []string
+)
+
+// pageOptions validates the pagination flags and returns them as methods.PageOptions
+func pageOptions() methods.PageOptions {
+	opts := methods.PageOptions{Strategy: pageStrategy, Offset: pageOffset, PageSize: pageSize}
+	for _, size := range pageDataSizes {
+		value, err := strconv.ParseUint(strings.TrimSpace(size), 10, 64)
+		if err != nil {
+			log.Fatalf("Invalid --page-data-sizes value %q: %v", size, err)
+		}
+		opts.DataSizes = append(opts.DataSizes, value)
+	}
+
+	if err := opts.Validate(); err != nil {
+		log.Fatalf("Invalid --paginate: %v", err)
+	}
+	return opts
+}
+
+// runPagination times a full enumeration of each program, one page after the other
+func runPagination() {
+	opts := pageOptions()
+	resolveProtocol()
+	resolveCompression()
+	resolveProxy()
+	if err := waitUntilReady(rpcURL); err != nil {
+		log.Fatal(err)
+	}
+
+	rpcTest := methods.NewRPCTestWithOptions(rpcURL, apiKey, clientOptions())
+	applyProgramFilters(rpcTest)
+
+	fmt.Fprintf(output, "Paginating %d programs with the %s strategy\n", len(programs), opts.Strategy)
+	fmt.Fprintf(output, "RPC URL: %s\n", rpcURL)
+
+	for _, program := range programs {
+		fmt.Fprintf(output, "\n📄 %s\n", program)
+
+		var latencies []time.Duration
+		total := 0
+		start := time.Now()
+		err := rpcTest.PaginateProgramAccounts(context.Background(), program, opts, func(page methods.PageResult) {
+			if page.Err != nil {
+				fmt.Fprintf(output, "   %-24s ❌ %v\n", page.Label, page.Err)
+				return
+			}
+			latencies = append(latencies, page.Latency)
+			total += page.Accounts
+			fmt.Fprintf(output, "   %-24s %8d accounts %12s\n", page.Label, page.Accounts, formatLatency(page.Latency))
+		})
+		elapsed := time.Since(start)
+
+		if err != nil {
+			fmt.Fprintf(output, "⚠️  Enumeration stopped after %d pages\n", len(latencies))
+		}
+		printPaginationSummary(latencies, total, elapsed)
+	}
+}
+
+// printPaginationSummary prints the end to end time of an enumeration and the spread of its page latencies
+func printPaginationSummary(latencies []time.Duration, total int, elapsed time.Duration) {
+	if len(latencies) == 0 {
+		return
+	}
+
+	var sum, slowest time.Duration
+	for _, latency := range latencies {
+		sum += latency
+		slowest = max(slowest, latency)
+	}
+
+	fmt.Fprintf(output, "   Pages: %d | Accounts: %d | Total: %s\n", len(latencies), total, formatLatency(elapsed))
+	fmt.Fprintf(output, "   Page latency: avg %s | p95 %s | max %s\n",
+		formatLatency(sum/time.Duration(len(latencies))), formatLatency(percentile(latencies, 95)), formatLatency(slowest))
+	if elapsed > 0 {
+		fmt.Fprintf(output, "   Throughput: %.0f accounts/s\n", float64(total)/elapsed.Seconds())
+	}
+}
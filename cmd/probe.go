@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"rpc_test/methods"
+)
+
+var (
+	// probeAccounts drops accounts the target serves as null before the run
+	probeAccounts bool
+
+	// probeWrite writes the accounts left by --probe-accounts back to the accounts file
+	probeWrite bool
+)
+
+// pruneDeadAccounts returns the accounts that exist on the target under --probe-accounts, writing them back
+// to accountsFile under --probe-write, and accounts as is when probing is off
+func pruneDeadAccounts(accounts []string, accountsFile string) ([]string, error) {
+	if !probeAccounts {
+		return accounts, nil
+	}
+
+	fmt.Fprintf(output, "  🔎 Probing %d accounts on the target...\n", len(accounts))
+	rpcTest := methods.NewRPCTestWithOptions(rpcURL, apiKey, clientOptions())
+	live, err := rpcTest.LiveAccounts(context.Background(), accounts, maxMultipleAccounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to probe accounts: %v", err)
+	}
+
+	pruned := len(accounts) - len(live)
+	if pruned == 0 {
+		fmt.Fprintf(output, "  ✅ All %d accounts exist\n", len(accounts))
+	} else {
+		fmt.Fprintf(output, "  ✂️  Pruned %d of %d accounts that don't exist (%.1f%%), %d left\n",
+			pruned, len(accounts), float64(pruned)/float64(len(accounts))*100, len(live))
+	}
+	logEvent("accounts_probed", "accounts", len(accounts), "pruned", pruned)
+	if len(live) == 0 {
+		return nil, fmt.Errorf("no account in %s exists on the target, seed again or use another account file", accountsFile)
+	}
+
+	if probeWrite && pruned > 0 {
+		if err := methods.WriteAccountFile(accountsFile, live); err != nil {
+			return nil, fmt.Errorf("failed to write probed accounts: %v", err)
+		}
+		fmt.Fprintf(output, "  💾 Wrote the %d live accounts back to %s\n", len(live), accountsFile)
+	}
+	return live, nil
+}
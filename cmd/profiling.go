@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	_ "net/http/pprof" // registers the /debug/pprof handlers on the default mux
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+var (
+	cpuProfile string
+	memProfile string
+	pprofAddr  string
+)
+
+// startProfiling starts the requested profilers and returns a function that writes the profiles
+func startProfiling() func() {
+	if pprofAddr != "" {
+		go func() {
+			if err := http.ListenAndServe(pprofAddr, nil); err != nil {
+				log.Printf("pprof server stopped: %v", err)
+			}
+		}()
+		fmt.Fprintf(output, "🔬 pprof available at: http://%s/debug/pprof/\n", pprofAddr)
+	}
+
+	var cpuFile *os.File
+	if cpuProfile != "" {
+		f, err := os.Create(cpuProfile)
+		if err != nil {
+			log.Fatalf("Failed to create CPU profile: %v", err)
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			log.Fatalf("Failed to start CPU profile: %v", err)
+		}
+		cpuFile = f
+	}
+
+	return func() {
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			cpuFile.Close()
+			fmt.Fprintf(output, "🔬 CPU profile written to: %s\n", cpuProfile)
+		}
+
+		if memProfile != "" {
+			f, err := os.Create(memProfile)
+			if err != nil {
+				log.Printf("Failed to create memory profile: %v", err)
+				return
+			}
+			defer f.Close()
+
+			// Collect garbage first so the heap profile reflects live allocations
+			runtime.GC()
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				log.Printf("Failed to write memory profile: %v", err)
+				return
+			}
+			fmt.Fprintf(output, "🔬 Memory profile written to: %s\n", memProfile)
+		}
+	}
+}
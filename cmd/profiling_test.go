@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunallFailureWritesCPUProfile(t *testing.T) {
+	// An unreadable config.json fails runall after profiling has started
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("config.json", []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cpuProfile = filepath.Join(t.TempDir(), "cpu.prof")
+	savedURL := rpcURL
+	t.Cleanup(func() { cpuProfile, rpcURL = "", savedURL })
+	rpcURL = "http://127.0.0.1:1"
+
+	if err := runallCmd.RunE(runallCmd, nil); err == nil {
+		t.Fatal("runall succeeded with a broken config.json")
+	}
+
+	// A profile that was never stopped is empty, a stopped one is a complete gzip stream
+	file, err := os.Open(cpuProfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("CPU profile is incomplete: %v", err)
+	}
+	if _, err := io.ReadAll(gz); err != nil {
+		t.Fatalf("CPU profile is incomplete: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"rpc_test/methods"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+var (
+	// programDiscriminators are PROGRAM=VALUE pairs restricting getProgramAccounts to one account type
+	programDiscriminators []string
+
+	// discriminatorSize is the byte width of the --programs-discriminator values
+	discriminatorSize int
+
+	// programInfo holds the validated per-program filters, keyed by program address
+	programInfo map[string]ProgramInfo
+)
+
+// defaultDiscriminatorSize is the width of an anchor account discriminator
+const defaultDiscriminatorSize = 8
+
+// filter translates the discriminator into a memcmp filter at offset 0
+func (p ProgramInfo) filter() (rpc.RPCFilter, error) {
+	width := p.DiscriminatorSize
+	if width == 0 {
+		width = defaultDiscriminatorSize
+	}
+	return methods.DiscriminatorFilter(p.Discriminator, width)
+}
+
+// resolveProgramFilters validates the configured program discriminators and the --programs-discriminator
+// overrides, failing on the first invalid one
+func resolveProgramFilters(configured map[string]ProgramInfo) error {
+	programInfo = make(map[string]ProgramInfo)
+	for program, info := range configured {
+		programInfo[program] = info
+	}
+
+	for _, pair := range programDiscriminators {
+		program, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("invalid --programs-discriminator %q: expected PROGRAM=VALUE", pair)
+		}
+		discriminator, err := strconv.ParseUint(strings.TrimSpace(value), 0, 64)
+		if err != nil {
+			return fmt.Errorf("invalid --programs-discriminator %q: %v", pair, err)
+		}
+		programInfo[strings.TrimSpace(program)] = ProgramInfo{Discriminator: discriminator, DiscriminatorSize: discriminatorSize}
+	}
+
+	addresses := make([]string, 0, len(programInfo))
+	for program := range programInfo {
+		addresses = append(addresses, program)
+	}
+	sort.Strings(addresses)
+
+	for _, program := range addresses {
+		info := programInfo[program]
+		if _, err := solana.PublicKeyFromBase58(program); err != nil {
+			return fmt.Errorf("invalid discriminator program %s: %v", program, err)
+		}
+		if _, err := info.filter(); err != nil {
+			return fmt.Errorf("invalid discriminator for program %s: %v", program, err)
+		}
+		fmt.Fprintf(output, "🔎 Filtering program %s to accounts with discriminator %#x\n", program, info.Discriminator)
+	}
+	return nil
+}
+
+// applyProgramFilters sets the resolved program filters on a client, before any worker uses it
+func applyProgramFilters(rpcTest *methods.RPCTest) {
+	for program, info := range programInfo {
+		// Already validated by resolveProgramFilters
+		filter, _ := info.filter()
+		rpcTest.SetProgramFilters(program, filter)
+	}
+}
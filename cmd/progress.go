@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// progressJSON replaces the progress bars with one JSON object per method and update on stderr
+var progressJSON bool
+
+// ProgressUpdate is the --progress-json line emitted for a method at every progress refresh
+type ProgressUpdate struct {
+	Method      string  `json:"method"`
+	Percent     float64 `json:"percent"`
+	Requests    int64   `json:"requests"`
+	RPS         float64 `json:"rps"`
+	SuccessRate float64 `json:"success_rate"`
+	Elapsed     float64 `json:"elapsed"`
+}
+
+// emitProgressJSON writes a progress update as one line of JSON to stderr
+func emitProgressJSON(method string, percent float64, successes, failures int64, elapsed time.Duration) {
+	update := ProgressUpdate{
+		Method:   method,
+		Percent:  min(percent, 100),
+		Requests: successes + failures,
+		Elapsed:  elapsed.Seconds(),
+	}
+	if update.Requests > 0 {
+		update.SuccessRate = float64(successes) / float64(update.Requests) * 100
+	}
+	if elapsed > 0 {
+		update.RPS = float64(update.Requests) / elapsed.Seconds()
+	}
+
+	line, err := json.Marshal(update)
+	if err != nil {
+		return
+	}
+	os.Stderr.Write(append(line, '\n'))
+}
+
+const (
+	// liveWindowSlots snapshots liveSlotInterval apart make up the ~5s window behind the "now" readouts
+	liveWindowSlots  = 10
+	liveSlotInterval = 500 * time.Millisecond
+)
+
+// liveSnapshot holds a method's cumulative counters at one point in time
+type liveSnapshot struct {
+	at           time.Time
+	requests     int64
+	successes    int64
+	totalLatency time.Duration
+}
+
+// liveWindow ring-buffers cumulative snapshots so the live display can show RPS and latency over the
+// last few seconds, which reacts to mid-run degradation that cumulative numbers average away
+type liveWindow struct {
+	ring  [liveWindowSlots]liveSnapshot
+	next  int
+	count int
+}
+
+// add records a snapshot, at most one per liveSlotInterval so the window spans a fixed time however often it is called
+func (w *liveWindow) add(snapshot liveSnapshot) {
+	if w.count > 0 && snapshot.at.Sub(w.ring[(w.next+liveWindowSlots-1)%liveWindowSlots].at) < liveSlotInterval {
+		return
+	}
+
+	w.ring[w.next] = snapshot
+	w.next = (w.next + 1) % liveWindowSlots
+	if w.count < liveWindowSlots {
+		w.count++
+	}
+}
+
+// rates returns the RPS and average latency between the oldest snapshot in the window and now
+func (w *liveWindow) rates(now liveSnapshot) (rps float64, avgLatency time.Duration) {
+	if w.count == 0 {
+		return 0, 0
+	}
+
+	oldest := w.ring[(w.next+liveWindowSlots-w.count)%liveWindowSlots]
+	if elapsed := now.at.Sub(oldest.at); elapsed > 0 {
+		rps = float64(now.requests-oldest.requests) / elapsed.Seconds()
+	}
+	if successes := now.successes - oldest.successes; successes > 0 {
+		avgLatency = (now.totalLatency - oldest.totalLatency) / time.Duration(successes)
+	}
+	return rps, avgLatency
+}
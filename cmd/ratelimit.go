@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	"rpc_test/methods"
+)
+
+var (
+	// adaptiveRate paces requests and backs off on HTTP 429 to find the endpoint's allowed rate
+	adaptiveRate      bool
+	adaptiveRateStart float64
+
+	// fixedRate paces requests at exactly this many per second, set by breakpoint for each of its steps
+	fixedRate float64
+)
+
+const (
+	// rateAdjustInterval is how often the limiter raises the rate when no 429 arrived
+	rateAdjustInterval = time.Second
+
+	// minAdaptiveRate keeps the limiter from backing off to a standstill
+	minAdaptiveRate = 1.0
+)
+
+// rateLimiter paces requests at a target rate, AIMD-style: the rate halves on a 429 and grows by a
+// fixed step every rateAdjustInterval without one, honoring the endpoint's Retry-After
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	step        float64
+	next        time.Time // earliest start of the next request
+	pausedUntil time.Time // Retry-After of the latest 429
+	limited     bool      // a 429 arrived since the last adjustment
+	fixed       bool      // hold the rate whatever the endpoint answers
+	rateLimited int64
+	history     []float64 // rate after each adjustment, to find where it settled
+	done        chan struct{}
+}
+
+// newRateLimiter returns a limiter starting at --adaptive-rate-start, or holding fixedRate when it is set,
+// nil when neither is in use
+func newRateLimiter() *rateLimiter {
+	if fixedRate > 0 {
+		return &rateLimiter{rate: fixedRate, fixed: true, done: make(chan struct{})}
+	}
+	if !adaptiveRate {
+		return nil
+	}
+	return &rateLimiter{
+		rate: adaptiveRateStart,
+		step: max(minAdaptiveRate, adaptiveRateStart/10),
+		done: make(chan struct{}),
+	}
+}
+
+// wait blocks until the next request may start, false when stop or quit closed first; nil-safe
+func (l *rateLimiter) wait(stop <-chan struct{}, quit <-chan struct{}) bool {
+	if l == nil {
+		return true
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	start := now
+	if l.next.After(start) {
+		start = l.next
+	}
+	if l.pausedUntil.After(start) {
+		start = l.pausedUntil
+	}
+	l.next = start.Add(time.Duration(float64(time.Second) / l.rate))
+	l.mu.Unlock()
+
+	if !start.After(now) {
+		return true
+	}
+	timer := time.NewTimer(start.Sub(now))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stop:
+		return false
+	case <-quit:
+		return false
+	}
+}
+
+// record halves the rate on the first 429 of an interval and pauses for its Retry-After; nil-safe
+func (l *rateLimiter) record(err error) {
+	if l == nil || err == nil || methods.ClassifyError(err) != methods.ErrorKindRateLimited {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rateLimited++
+	if l.fixed {
+		return
+	}
+	if !l.limited {
+		l.limited = true
+		l.rate = max(l.rate/2, minAdaptiveRate)
+		logEvent("rate_adjusted", "rps", l.rate, "reason", "rate_limited")
+	}
+	if retryAfter := methods.RetryAfter(err); retryAfter > 0 {
+		if resume := time.Now().Add(retryAfter); resume.After(l.pausedUntil) {
+			l.pausedUntil = resume
+		}
+	}
+}
+
+// run raises the rate every rateAdjustInterval without a 429 until stop is closed
+func (l *rateLimiter) run(stop <-chan struct{}) {
+	defer close(l.done)
+
+	ticker := time.NewTicker(rateAdjustInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			l.mu.Lock()
+			if !l.limited {
+				l.rate += l.step
+			}
+			l.limited = false
+			l.history = append(l.history, l.rate)
+			l.mu.Unlock()
+		}
+	}
+}
+
+// settled averages the rate over the last third of the run, once run has returned
+func (l *rateLimiter) settled() float64 {
+	if len(l.history) == 0 {
+		return l.rate
+	}
+
+	tail := l.history[len(l.history)-max(1, len(l.history)/3):]
+	var total float64
+	for _, rate := range tail {
+		total += rate
+	}
+	return total / float64(len(tail))
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"rpc_test/methods"
+
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rawMethod string
+	rawParams string
+
+	// rawParamList is --params decoded once before the run
+	rawParamList []interface{}
+)
+
+// rawCmd represents the raw command
+var rawCmd = &cobra.Command{
+	Use:   "raw",
+	Short: "Run performance tests for any RPC method with raw JSON params",
+	Long: `Run stress tests against Solana RPC endpoints using any JSON-RPC method, including the
+ones this tool has no dedicated command for.
+
+The method and its params are sent as given, so any method the endpoint serves can be
+benchmarked. A single probe request is sent first: when the endpoint rejects the call
+(unknown method, invalid params) its JSON-RPC error is printed and the test stops before
+generating load.
+
+Features:
+• Any Method: Covers the long tail of RPC methods without a dedicated wrapper
+• Raw Params: --params takes the JSON array of positional params
+• Null Results: Requests answered with a null result are reported as Empty (null)
+• Comprehensive Metrics: Success rate, RPS, and latency statistics with dynamic unit formatting
+
+Examples:
+  # Benchmark getBlockHeight, which takes no params
+  rpc_test raw --method getBlockHeight --url https://your-target-rpc.com --concurrency 5
+
+  # Benchmark getBalance for one account at confirmed commitment
+  rpc_test raw --method getBalance --params '["7Xnw7aDxJu1CxPPEkz9ttfGSn2bpH3R1GYYziJxTCv3e", {"commitment": "confirmed"}]'`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if rawMethod == "" {
+			log.Fatalf("--method is required")
+		}
+
+		params, err := parseRawParams(rawParams)
+		if err != nil {
+			log.Fatalf("Invalid --params: %v", err)
+		}
+		rawParamList = params
+
+		RunMethodTest("raw")
+	},
+}
+
+// parseRawParams decodes --params, which must be a JSON array, empty for no params
+func parseRawParams(raw string) ([]interface{}, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	var params []interface{}
+	if err := json.Unmarshal([]byte(raw), &params); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of params: %v", err)
+	}
+	return params, nil
+}
+
+// probeRawMethod sends one request so a rejected call fails with the endpoint's error instead of a failed run
+func probeRawMethod(rpcTest *methods.RPCTest) {
+	_, err := rpcTest.RawCall(context.Background(), rawMethod, rawParamList)
+
+	var rpcErr *jsonrpc.RPCError
+	if errors.As(err, &rpcErr) {
+		log.Fatalf("%s was rejected by the RPC: code %d: %s", rawMethod, rpcErr.Code, rpcErr.Message)
+	}
+	if err != nil {
+		fmt.Fprintf(output, "⚠️  Probe request failed, running anyway: %v\n", err)
+	}
+	fmt.Fprintf(output, "Raw method: %s, params: %s\n", rawMethod, rawParamsLabel())
+}
+
+// rawParamsLabel prints --params for the run header
+func rawParamsLabel() string {
+	if rawParams == "" {
+		return "none"
+	}
+	return rawParams
+}
+
+func init() {
+	RootCmd.AddCommand(rawCmd)
+
+	rawCmd.Flags().StringVar(&rawMethod, "method", "", "JSON-RPC method to call, e.g. getBlockHeight (required)")
+	rawCmd.Flags().StringVar(&rawParams, "params", "", "Params as a JSON array, e.g. '[\"ADDRESS\", {\"commitment\": \"confirmed\"}]' (default: no params)")
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rpc_test/methods"
+)
+
+var (
+	// waitForReady polls the target until it is healthy before the test starts
+	waitForReady bool
+	waitTimeout  time.Duration
+)
+
+const (
+	// readyInitialBackoff is the first pause between readiness polls, doubled up to readyMaxBackoff
+	readyInitialBackoff = 250 * time.Millisecond
+	readyMaxBackoff     = 5 * time.Second
+)
+
+// waitUntilReady blocks until targetURL passes a health check when --wait-for-ready is set,
+// failing with the last error once --wait-timeout passes
+func waitUntilReady(targetURL string) error {
+	if !waitForReady {
+		return nil
+	}
+	if waitTimeout <= 0 {
+		return fmt.Errorf("--wait-timeout must be positive")
+	}
+
+	rpcTest := methods.NewRPCTestWithOptions(targetURL, apiKey, clientOptions())
+	fmt.Fprintf(output, "⏳ Waiting up to %s for %s to become ready\n", waitTimeout, redactURL(targetURL))
+
+	start := time.Now()
+	deadline := start.Add(waitTimeout)
+	backoff := readyInitialBackoff
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		err := rpcTest.CheckHealth(ctx)
+		cancel()
+
+		if err == nil {
+			waited := time.Since(start)
+			fmt.Fprintf(output, "✅ Endpoint ready after %s (%d checks)\n", waited.Round(time.Millisecond), attempt)
+			logEvent("endpoint_ready", "url", redactURL(targetURL), "waited_s", waited.Seconds(), "checks", attempt)
+			return nil
+		}
+
+		if time.Now().Add(backoff).After(deadline) {
+			return fmt.Errorf("❌ %s was not ready after %s (%d checks): %v", redactURL(targetURL), waitTimeout, attempt, err)
+		}
+		fmt.Fprintf(output, "   Not ready yet (%v), retrying in %s\n", err, backoff)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, readyMaxBackoff)
+	}
+}
@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"rpc_test/methods"
+)
+
+var (
+	// recordPath writes every issued request to this file when set, for --replay
+	recordPath string
+
+	// replayPath re-issues the requests recorded in this file instead of running a load test
+	replayPath string
+
+	// replayTiming issues replayed requests at their recorded offsets instead of one after the other
+	replayTiming bool
+)
+
+// maxRecordedLine bounds one line of a recording, a full getMultipleAccounts batch is well under it
+const maxRecordedLine = 1 << 20
+
+// recordedRequest is one issued request, written as a compact JSON line
+type recordedRequest struct {
+	OffsetMs float64  `json:"t"` // milliseconds from the start of the recording to when the request was issued
+	Method   string   `json:"m"`
+	Args     []string `json:"a,omitempty"`
+	Error    string   `json:"e,omitempty"` // the request failed with this in the recorded run
+}
+
+// requestRecorder appends every issued request to --record, nil when the flag is not set. Unlike the error
+// log it never drops entries, a replay needs the whole sequence.
+type requestRecorder struct {
+	mu      sync.Mutex
+	start   time.Time
+	file    *os.File
+	buf     *bufio.Writer
+	written int
+	err     error
+}
+
+// recorder is the running recorder, nil when --record is not set
+var recorder *requestRecorder
+
+// startRecording creates --record when it is set
+func startRecording() error {
+	if recordPath == "" {
+		return nil
+	}
+	if replayPath != "" {
+		return fmt.Errorf("--record and --replay can't be combined")
+	}
+
+	file, err := os.Create(recordPath)
+	if err != nil {
+		return fmt.Errorf("failed to create request recording: %v", err)
+	}
+	recorder = &requestRecorder{start: time.Now(), file: file, buf: bufio.NewWriter(file)}
+	fmt.Fprintf(output, "Recording requests to: %s\n", recordPath)
+	return nil
+}
+
+// stopRecording writes out and closes the recording
+func stopRecording() {
+	if recorder == nil {
+		return
+	}
+
+	err := recorder.err
+	if flushErr := recorder.buf.Flush(); err == nil {
+		err = flushErr
+	}
+	if closeErr := recorder.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Fatalf("Failed to write request recording: %v", err)
+	}
+	fmt.Fprintf(output, "🎞️  Recorded %d requests to %s, replay them with --replay %s\n", recorder.written, recordPath, recordPath)
+	recorder = nil
+}
+
+// record appends a request issued at start; nil-safe
+func (r *requestRecorder) record(methodName string, args []string, start time.Time, err error) {
+	if r == nil {
+		return
+	}
+
+	entry := recordedRequest{OffsetMs: durationMs(start.Sub(r.start)), Method: methodName, Args: args}
+	if err != nil {
+		entry.Error, _ = truncatePayload(err.Error())
+	}
+	line, marshalErr := json.Marshal(entry)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	if marshalErr != nil {
+		r.err = marshalErr
+		return
+	}
+	if _, r.err = r.buf.Write(append(line, '\n')); r.err == nil {
+		r.written++
+	}
+}
+
+// readRecording reads the requests of a --record file in the order they were issued
+func readRecording(path string) ([]recordedRequest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var requests []recordedRequest
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64<<10), maxRecordedLine)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var request recordedRequest
+		if err := json.Unmarshal([]byte(line), &request); err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+		if _, ok := methods.LookupMethod(request.Method); !ok {
+			return nil, fmt.Errorf("line %d: unknown method %q", lineNumber, request.Method)
+		}
+		requests = append(requests, request)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Workers write a request when it completes, so the file is only roughly in issue order
+	sort.SliceStable(requests, func(i, j int) bool { return requests[i].OffsetMs < requests[j].OffsetMs })
+	return requests, nil
+}
+
+// replayOutcome is the result of one replayed request
+type replayOutcome struct {
+	request recordedRequest
+	latency time.Duration
+	err     error
+}
+
+// runReplay re-issues the requests of --replay against targetURL, only those of methodName when it is set,
+// and reports how their outcomes compare with the recording
+func runReplay(targetURL, methodName string) {
+	if recordPath != "" {
+		log.Fatalf("--record and --replay can't be combined")
+	}
+	if protocol == protocolBoth || compression == compressionBoth {
+		log.Fatalf("comparison modes (both) are not supported by --replay")
+	}
+
+	recorded, err := readRecording(replayPath)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", replayPath, err)
+	}
+	var requests []recordedRequest
+	for _, request := range recorded {
+		if methodName == "" || request.Method == methodName {
+			requests = append(requests, request)
+		}
+	}
+	if len(requests) == 0 && methodName != "" {
+		log.Fatalf("%s has no %s requests to replay, replay it with runall for every method", replayPath, methodName)
+	}
+	if len(requests) == 0 {
+		log.Fatalf("%s has no requests to replay", replayPath)
+	}
+
+	rpcTest := methods.NewRPCTestWithOptions(targetURL, apiKey, clientOptions())
+	applyProgramFilters(rpcTest)
+	prepared := make(map[string]bool)
+	for _, request := range requests {
+		if prepared[request.Method] {
+			continue
+		}
+		prepared[request.Method] = true
+		switch request.Method {
+		case "getFeeForMessage":
+			prepareFeeMessage(rpcTest)
+		case "raw":
+			probeRawMethod(rpcTest)
+		}
+	}
+	rpcTest.SetCallOptions(callOptions())
+
+	mode := "one after the other"
+	if replayTiming {
+		mode = "at their recorded times"
+	}
+	fmt.Fprintf(output, "🎞️  Replaying %d requests from %s against %s, %s\n", len(requests), replayPath, redactURL(targetURL), mode)
+	logEvent("replay_started", "file", replayPath, "url", redactURL(targetURL), "requests", len(requests), "timing", replayTiming)
+
+	outcomes := make([]replayOutcome, len(requests))
+	issue := func(i int) {
+		start := time.Now()
+		_, err := methods.Dispatch(context.Background(), requests[i].Method, rpcTest, requests[i].Args...)
+		outcomes[i] = replayOutcome{request: requests[i], latency: time.Since(start), err: err}
+	}
+
+	start := time.Now()
+	if replayTiming {
+		var wg sync.WaitGroup
+		for i, request := range requests {
+			// Offsets count from the first request, so setup time before it in the recorded run is skipped
+			offset := time.Duration((request.OffsetMs - requests[0].OffsetMs) * float64(time.Millisecond))
+			time.Sleep(time.Until(start.Add(offset)))
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				issue(i)
+			}(i)
+		}
+		wg.Wait()
+	} else {
+		for i := range requests {
+			issue(i)
+		}
+	}
+
+	printReplay(outcomes, time.Since(start))
+}
+
+// maxReplayChanges bounds the requests whose changed outcome the replay report lists one by one
+const maxReplayChanges = 10
+
+// printReplay prints the latency and failures of each replayed method, then the requests whose outcome
+// differs from the recording
+func printReplay(outcomes []replayOutcome, elapsed time.Duration) {
+	type methodStats struct {
+		requests, failures int
+		latencies          []time.Duration
+	}
+	stats := make(map[string]*methodStats)
+	var order []string
+	var failures, newFailures, resolved int
+	var changes []string
+	for i, outcome := range outcomes {
+		s, ok := stats[outcome.request.Method]
+		if !ok {
+			s = &methodStats{}
+			stats[outcome.request.Method] = s
+			order = append(order, outcome.request.Method)
+		}
+		s.requests++
+		s.latencies = append(s.latencies, outcome.latency)
+		if outcome.err != nil {
+			s.failures++
+			failures++
+		}
+
+		change := ""
+		switch recordedFailed := outcome.request.Error != ""; {
+		case outcome.err != nil && !recordedFailed:
+			newFailures++
+			change = "now fails: " + outcome.err.Error()
+		case outcome.err == nil && recordedFailed:
+			resolved++
+			change = "now succeeds, recorded: " + outcome.request.Error
+		}
+		if change != "" && len(changes) < maxReplayChanges {
+			changes = append(changes, fmt.Sprintf("#%d %s: %s", i+1, outcome.request.Method, change))
+		}
+	}
+
+	fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(output, "🎞️  REPLAY: %d requests in %s\n", len(outcomes), elapsed.Round(time.Millisecond))
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(output, "%-22s %10s %10s %12s %12s\n", "Method", "Requests", "Failed", "p50", "p95")
+	for _, methodName := range order {
+		s := stats[methodName]
+		fmt.Fprintf(output, "%-22s %10d %10d %12s %12s\n", methodName, s.requests, s.failures,
+			formatLatency(percentile(s.latencies, 50)), formatLatency(percentile(s.latencies, 95)))
+	}
+
+	if newFailures == 0 && resolved == 0 {
+		fmt.Fprintln(output, "✅ Every request had the same outcome as in the recording")
+	} else {
+		fmt.Fprintf(output, "⚠️  %d requests fail that succeeded in the recording, %d succeed that failed\n", newFailures, resolved)
+		for _, change := range changes {
+			fmt.Fprintf(output, "   %s\n", change)
+		}
+		if more := newFailures + resolved - len(changes); more > 0 {
+			fmt.Fprintf(output, "   ... and %d more\n", more)
+		}
+	}
+
+	logEvent("replay_finished",
+		"requests", len(outcomes),
+		"failures", failures,
+		"new_failures", newFailures,
+		"resolved_failures", resolved,
+		"duration_s", elapsed.Seconds(),
+	)
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// trackResources samples the load generator's CPU, memory and open files during the run
+	trackResources bool
+
+	// hostResources also samples the CPU of the whole host under --resources
+	hostResources bool
+
+	// cpuThreshold is the CPU percent of all cores above which the load generator counts as the bottleneck
+	cpuThreshold float64
+)
+
+// resourceInterval is how often --resources takes a sample
+const resourceInterval = time.Second
+
+// clockTicks is USER_HZ, the unit of the CPU times in /proc, 100 on every mainstream Linux build
+const clockTicks = 100
+
+// ResourceSummary is the peak resource usage of the load generator during a run, CPU in percent of all cores
+type ResourceSummary struct {
+	Samples        int     `json:"samples"`
+	CPUs           int     `json:"cpus"`
+	AvgCPU         float64 `json:"avg_cpu_percent"`
+	PeakCPU        float64 `json:"peak_cpu_percent"`
+	PeakHostCPU    float64 `json:"peak_host_cpu_percent,omitempty"` // under --host-resources
+	PeakRSS        int64   `json:"peak_rss_bytes,omitempty"`        // Linux only
+	PeakHeap       uint64  `json:"peak_heap_bytes"`
+	PeakGoroutines int     `json:"peak_goroutines"`
+	PeakOpenFiles  int     `json:"peak_open_files,omitempty"` // Linux only
+	CPUThreshold   float64 `json:"cpu_threshold_percent"`
+	CPUBound       bool    `json:"cpu_bound"` // the peak CPU went over the threshold, so the RPS is a floor
+}
+
+// resourceMonitor samples the process, and the host under --host-resources, until stopped
+type resourceMonitor struct {
+	mu      sync.Mutex
+	summary ResourceSummary
+	cpuSum  float64
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// resources is the running monitor, nil when --resources is not set
+var resources *resourceMonitor
+
+// resourceSummary is the summary of the last run under --resources, nil before one finished
+var resourceSummary *ResourceSummary
+
+// startResources starts sampling when --resources is set
+func startResources() error {
+	if !trackResources {
+		return nil
+	}
+	if cpuThreshold <= 0 || cpuThreshold > 100 {
+		return fmt.Errorf("invalid --cpu-threshold %.1f, expected a percent above 0 and at most 100", cpuThreshold)
+	}
+
+	resources = &resourceMonitor{
+		summary: ResourceSummary{CPUs: runtime.NumCPU(), CPUThreshold: cpuThreshold},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go resources.run()
+	return nil
+}
+
+// stopResources stops sampling and keeps the summary in resourceSummary for the report and --output
+func stopResources() {
+	if resources == nil {
+		return
+	}
+
+	close(resources.stop)
+	<-resources.done
+	summary := resources.summary
+	if summary.Samples > 0 {
+		summary.AvgCPU = resources.cpuSum / float64(summary.Samples)
+	}
+	summary.CPUBound = summary.PeakCPU > summary.CPUThreshold
+	resourceSummary = &summary
+	resources = nil
+
+	logEvent("resources_sampled",
+		"samples", summary.Samples,
+		"avg_cpu_percent", summary.AvgCPU,
+		"peak_cpu_percent", summary.PeakCPU,
+		"peak_host_cpu_percent", summary.PeakHostCPU,
+		"peak_rss_bytes", summary.PeakRSS,
+		"peak_heap_bytes", summary.PeakHeap,
+		"peak_goroutines", summary.PeakGoroutines,
+		"peak_open_files", summary.PeakOpenFiles,
+		"cpu_bound", summary.CPUBound,
+	)
+}
+
+// run takes a sample every resourceInterval until stopped
+func (m *resourceMonitor) run() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(resourceInterval)
+	defer ticker.Stop()
+
+	lastAt := time.Now()
+	lastCPU, cpuOK := processCPUSeconds()
+	lastBusy, lastTotal, hostOK := hostCPUTicks()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case now := <-ticker.C:
+			var mem runtime.MemStats
+			runtime.ReadMemStats(&mem)
+
+			m.mu.Lock()
+			s := &m.summary
+			s.Samples++
+			if cpu, ok := processCPUSeconds(); ok && cpuOK {
+				percent := (cpu - lastCPU) / now.Sub(lastAt).Seconds() / float64(s.CPUs) * 100
+				m.cpuSum += percent
+				s.PeakCPU = max(s.PeakCPU, percent)
+				lastAt, lastCPU = now, cpu
+			}
+			if hostResources {
+				if busy, total, ok := hostCPUTicks(); ok && hostOK && total > lastTotal {
+					s.PeakHostCPU = max(s.PeakHostCPU, float64(busy-lastBusy)/float64(total-lastTotal)*100)
+					lastBusy, lastTotal = busy, total
+				}
+			}
+			s.PeakRSS = max(s.PeakRSS, processRSS())
+			s.PeakHeap = max(s.PeakHeap, mem.HeapInuse)
+			s.PeakGoroutines = max(s.PeakGoroutines, runtime.NumGoroutine())
+			s.PeakOpenFiles = max(s.PeakOpenFiles, openFiles())
+			m.mu.Unlock()
+		}
+	}
+}
+
+// processCPUSeconds returns the user and system CPU time of the process from /proc/self/stat, ok is false
+// where there is no /proc
+func processCPUSeconds() (float64, bool) {
+	data, err := os.ReadFile("/proc/self/stat")
+	if err != nil {
+		return 0, false
+	}
+	// The command name in parentheses may hold spaces, the fields after it are fixed: utime and stime
+	// are the 12th and 13th after the closing parenthesis
+	end := strings.LastIndexByte(string(data), ')')
+	if end < 0 {
+		return 0, false
+	}
+	fields := strings.Fields(string(data[end+1:]))
+	if len(fields) < 13 {
+		return 0, false
+	}
+	utime, err1 := strconv.ParseUint(fields[11], 10, 64)
+	stime, err2 := strconv.ParseUint(fields[12], 10, 64)
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+	return float64(utime+stime) / clockTicks, true
+}
+
+// hostCPUTicks returns the busy and total CPU time of the host from /proc/stat, ok is false where there is
+// no /proc
+func hostCPUTicks() (busy, total uint64, ok bool) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return 0, 0, false
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return 0, 0, false
+	}
+	for i, field := range fields[1:] {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		total += value
+		// idle and iowait are the 4th and 5th values
+		if i != 3 && i != 4 {
+			busy += value
+		}
+	}
+	return busy, total, true
+}
+
+// processRSS returns the resident memory of the process from /proc/self/status, 0 where there is no /proc
+func processRSS() int64 {
+	data, err := os.ReadFile("/proc/self/status")
+	if err != nil {
+		return 0
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if rest, ok := strings.CutPrefix(line, "VmRSS:"); ok {
+			fields := strings.Fields(rest)
+			if len(fields) == 0 {
+				return 0
+			}
+			kb, err := strconv.ParseInt(fields[0], 10, 64)
+			if err != nil {
+				return 0
+			}
+			return kb << 10
+		}
+	}
+	return 0
+}
+
+// openFiles returns the file descriptors the process has open, connections included, 0 where there is no /proc
+func openFiles() int {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return 0
+	}
+	return len(entries)
+}
+
+// printResources prints the resource summary of the run under --resources, warning when the load generator
+// was the bottleneck
+func printResources() {
+	s := resourceSummary
+	if s == nil || s.Samples == 0 {
+		return
+	}
+
+	fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "🖥️  LOAD GENERATOR RESOURCES")
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(output, "CPU:          avg %.1f%%, peak %.1f%% of %d cores\n", s.AvgCPU, s.PeakCPU, s.CPUs)
+	if s.PeakHostCPU > 0 {
+		fmt.Fprintf(output, "Host CPU:     peak %.1f%%\n", s.PeakHostCPU)
+	}
+	if s.PeakRSS > 0 {
+		fmt.Fprintf(output, "Memory:       peak %s resident, %s heap\n", formatBytes(s.PeakRSS), formatBytes(int64(s.PeakHeap)))
+	} else {
+		fmt.Fprintf(output, "Memory:       peak %s heap\n", formatBytes(int64(s.PeakHeap)))
+	}
+	fmt.Fprintf(output, "Goroutines:   peak %d\n", s.PeakGoroutines)
+	if s.PeakOpenFiles > 0 {
+		fmt.Fprintf(output, "Open files:   peak %d\n", s.PeakOpenFiles)
+	}
+	if s.CPUBound {
+		fmt.Fprintf(output, "⚠️  The load generator's CPU peaked over %.0f%%: it was likely the bottleneck, so the measured RPS is a floor, not the endpoint's ceiling\n", s.CPUThreshold)
+	}
+}
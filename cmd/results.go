@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// resultsOutput is the --output file results are saved to as JSON, empty to skip saving
+var resultsOutput string
+
+// resultsAppend appends each run to --output as one JSON line instead of overwriting it
+var resultsAppend bool
+
+// ResultsFile is the JSON document written by --output, the run metadata inlined at the top level
+type ResultsFile struct {
+	RunMetadata
+	Results   []MethodResult   `json:"results"`
+	Slowest   []SlowRequest    `json:"slowest,omitempty"`   // the --slowest slowest requests, slowest first
+	Resources *ResourceSummary `json:"resources,omitempty"` // the load generator's own usage under --resources
+}
+
+// MethodResult is the JSON form of a TestResult, latencies in milliseconds
+type MethodResult struct {
+	Method         string          `json:"method"`
+	DurationSecs   float64         `json:"duration_s"`
+	TotalRequests  int64           `json:"total_requests"`
+	SuccessCount   int64           `json:"success_count"`
+	FailureCount   int64           `json:"failure_count"`
+	EmptyCount     int64           `json:"empty_count"`
+	PartialCount   int64           `json:"partial_response_count"`
+	CountedAccts   int64           `json:"counted_accounts,omitempty"`
+	RequestsPerSec float64         `json:"requests_per_sec"`
+	SuccessRate    float64         `json:"success_rate"`
+	MinLatencyMs   float64         `json:"min_latency_ms"`
+	MaxLatencyMs   float64         `json:"max_latency_ms"`
+	AvgLatencyMs   float64         `json:"avg_latency_ms"`
+	P95LatencyMs   float64         `json:"p95_latency_ms"`
+	WireBytes      int64           `json:"wire_bytes"`
+	DecodedBytes   int64           `json:"decoded_bytes"`
+	SLAConcurrency float64         `json:"sla_concurrency,omitempty"`
+	SLARPS         float64         `json:"sla_requests_per_sec,omitempty"`
+	SettledRPS     float64         `json:"settled_rps,omitempty"`
+	SLAThresholdMs int             `json:"sla_threshold_ms,omitempty"`
+	SLACompliance  *float64        `json:"sla_compliance,omitempty"` // percent of successes within sla_threshold_ms
+	AvgSlotLag     *float64        `json:"avg_slot_lag,omitempty"`   // slots behind the chain tip under --track-slot
+	StaleResponses int64           `json:"stale_responses,omitempty"`
+	ConnsOpened    int64           `json:"connections_opened,omitempty"`
+	ConnWaits      int64           `json:"connection_waits,omitempty"`
+	Reconnects     int64           `json:"reconnects,omitempty"`
+	Retries        int64           `json:"retries,omitempty"`
+	ErrorCodes     []RPCErrorCount `json:"error_codes,omitempty"`
+	Error          string          `json:"error,omitempty"`
+}
+
+// percentile returns the p-th percentile of the latencies, 0 when there are none
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	index := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
+
+// maxRetainedLatencies bounds the latencies kept for percentiles so long runs don't grow without limit
+const maxRetainedLatencies = 1 << 20
+
+// retainLatency appends latency until maxRetainedLatencies are kept, then overwrites a random one so the
+// kept latencies stay a uniform sample of all seen successful requests
+func retainLatency(latencies []time.Duration, latency time.Duration, seen int64) []time.Duration {
+	if len(latencies) < maxRetainedLatencies {
+		return append(latencies, latency)
+	}
+	if i := rand.Int63n(seen); i < int64(len(latencies)) {
+		latencies[i] = latency
+	}
+	return latencies
+}
+
+// durationMs converts a latency to fractional milliseconds
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// newMethodResult converts a TestResult to its JSON form
+func newMethodResult(result TestResult) MethodResult {
+	entry := MethodResult{
+		Method:         result.MethodName,
+		DurationSecs:   result.Duration.Seconds(),
+		TotalRequests:  result.TotalRequests,
+		SuccessCount:   result.SuccessCount,
+		FailureCount:   result.FailureCount,
+		EmptyCount:     result.EmptyCount,
+		PartialCount:   result.PartialResponseCount,
+		CountedAccts:   result.CountedAccounts,
+		RequestsPerSec: result.RequestsPerSec,
+		SuccessRate:    result.SuccessRate,
+		MinLatencyMs:   durationMs(result.MinLatency),
+		MaxLatencyMs:   durationMs(result.MaxLatency),
+		AvgLatencyMs:   durationMs(result.AvgLatency),
+		P95LatencyMs:   durationMs(result.P95Latency),
+		WireBytes:      result.WireBytes,
+		DecodedBytes:   result.DecodedBytes,
+		SLAConcurrency: result.SLAConcurrency,
+		SLARPS:         result.SLARequestsPerSec,
+		SettledRPS:     result.SettledRate,
+		ConnsOpened:    result.ConnectionsOpened,
+		ConnWaits:      result.ConnectionWaits,
+		Reconnects:     result.Reconnects,
+		Retries:        result.Retries,
+		StaleResponses: result.StaleResponses,
+		ErrorCodes:     sortedErrorCodes(result.ErrorCodes),
+		Error:          result.Error,
+	}
+	if compliance, ok := slaCompliance(result); ok {
+		entry.SLAThresholdMs, entry.SLACompliance = slaThreshold, &compliance
+	}
+	if result.SlotLagSamples > 0 {
+		lag := result.AvgSlotLag
+		entry.AvgSlotLag = &lag
+	}
+	return entry
+}
+
+// saveResults writes results to --output when it is set
+func saveResults(meta RunMetadata, results []TestResult) {
+	if resultsOutput == "" {
+		return
+	}
+
+	file := ResultsFile{RunMetadata: meta, Slowest: slowest.list(), Resources: resourceSummary}
+	for _, result := range results {
+		file.Results = append(file.Results, newMethodResult(result))
+	}
+
+	if resultsAppend {
+		if err := appendResults(file); err != nil {
+			fmt.Fprintf(output, "⚠️  Failed to append results to %s: %v\n", resultsOutput, err)
+			return
+		}
+		fmt.Fprintf(output, "💾 Results appended to: %s\n", resultsOutput)
+		return
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		fmt.Fprintf(output, "⚠️  Failed to encode results: %v\n", err)
+		return
+	}
+	if err := os.WriteFile(resultsOutput, data, 0644); err != nil {
+		fmt.Fprintf(output, "⚠️  Failed to write results to %s: %v\n", resultsOutput, err)
+		return
+	}
+	fmt.Fprintf(output, "💾 Results saved to: %s\n", resultsOutput)
+}
+
+// appendResults adds file to --output as a single JSON line. The line goes out in one O_APPEND write
+// so concurrent runs appending to the same file don't interleave their lines.
+func appendResults(file ResultsFile) error {
+	data, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("failed to encode results: %v", err)
+	}
+
+	out, err := os.OpenFile(resultsOutput, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err := out.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
+
+// loadResultsLines reads every run of a results file written with --output-append, one JSON object per line
+func loadResultsLines(path string) ([]*ResultsFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results file: %v", err)
+	}
+
+	var files []*ResultsFile
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var file ResultsFile
+		if err := json.Unmarshal([]byte(line), &file); err != nil {
+			return nil, fmt.Errorf("failed to parse line %d of %s: %v", i+1, path, err)
+		}
+		files = append(files, &file)
+	}
+	return files, nil
+}
+
+// loadResults reads a results file written by --output
+func loadResults(path string) (*ResultsFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results file: %v", err)
+	}
+
+	var file ResultsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse results file %s: %v", path, err)
+	}
+	return &file, nil
+}
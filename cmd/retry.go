@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"time"
+
+	"rpc_test/methods"
+)
+
+var (
+	// retries re-sends a request that failed in transport up to this many times, 0 counts every failure at once
+	retries      int
+	retryBackoff time.Duration
+
+	// retryJitter waits a random time within each backoff window so workers that failed together don't retry
+	// in lockstep
+	retryJitter bool
+)
+
+// maxRetries caps --retries so a dead endpoint can't multiply the load
+const maxRetries = 5
+
+// validateRetries exits when --retries or --retry-backoff is out of range
+func validateRetries() {
+	if retries < 0 || retries > maxRetries {
+		log.Fatalf("Invalid --retries %d (expected 0 to %d)", retries, maxRetries)
+	}
+	if retryBackoff < 0 {
+		log.Fatalf("Invalid --retry-backoff %s (must not be negative)", retryBackoff)
+	}
+}
+
+// dispatchWithRetries sends a request, re-sending it up to --retries times while it fails in transport. Each
+// retry backs off with delays drawn from rng, and one whose delay would run past end is not sent.
+func dispatchWithRetries(ctx context.Context, methodName string, rpcTest *methods.RPCTest, args []string, rng *rand.Rand, end time.Time) (methods.CallResult, int, error) {
+	outcome, err := methods.Dispatch(ctx, methodName, rpcTest, args...)
+	retried := 0
+	for ; err != nil && retried < retries && methods.IsTransportErrorKind(methods.ClassifyError(err)); retried++ {
+		delay := methods.RetryBackoff(rng, retryBackoff, retried, retryJitter)
+		if time.Now().Add(delay).After(end) {
+			break
+		}
+		time.Sleep(delay)
+		outcome, err = methods.Dispatch(ctx, methodName, rpcTest, args...)
+	}
+	return outcome, retried, err
+}
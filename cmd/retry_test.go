@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"rpc_test/methods"
+)
+
+func TestDispatchWithRetries(t *testing.T) {
+	// The endpoint fails the first two requests in transport, then answers
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 2 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":{"context":{"slot":5},"value":{"data":["","base64"],"executable":false,"lamports":1,"owner":"11111111111111111111111111111111","rentEpoch":0}}}`)
+	}))
+	t.Cleanup(server.Close)
+	rpcTest := methods.NewRPCTestWithOptions(server.URL, "", methods.ClientOptions{})
+	args := []string{"SysvarRent111111111111111111111111111111111"}
+	rng := rand.New(rand.NewSource(1))
+
+	originalRetries, originalBackoff := retries, retryBackoff
+	t.Cleanup(func() { retries, retryBackoff = originalRetries, originalBackoff })
+	retryBackoff = time.Millisecond
+
+	// One retry is not enough
+	retries = 1
+	if _, retried, err := dispatchWithRetries(context.Background(), "getAccountInfo", rpcTest, args, rng, time.Now().Add(time.Minute)); err == nil || retried != 1 {
+		t.Fatalf("got %d retries and error %v, want 1 retry and the transport error", retried, err)
+	}
+
+	// The request succeeds on its first retry now that the endpoint answers
+	retries = 2
+	requests.Store(1)
+	if _, retried, err := dispatchWithRetries(context.Background(), "getAccountInfo", rpcTest, args, rng, time.Now().Add(time.Minute)); err != nil || retried != 1 {
+		t.Fatalf("got %d retries and error %v, want success after 1 retry", retried, err)
+	}
+
+	// A retry whose backoff would run past the end of the test is not sent
+	requests.Store(0)
+	if _, retried, err := dispatchWithRetries(context.Background(), "getAccountInfo", rpcTest, args, rng, time.Now()); err == nil || retried != 0 {
+		t.Fatalf("got %d retries and error %v, want no retry past the end", retried, err)
+	}
+	if sent := requests.Load(); sent != 1 {
+		t.Fatalf("endpoint received %d requests, want only the first", sent)
+	}
+}
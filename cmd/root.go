@@ -3,6 +3,9 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
+
+	"rpc_test/methods"
 
 	"github.com/spf13/cobra"
 )
@@ -24,8 +27,16 @@ Features:
 
 Supported RPC Methods:
 • getAccountInfo: Test account information retrieval with account rotation
-• getMultipleAccounts: Test batch account retrieval (5-15 accounts per request)
+• getMultipleAccounts: Test batch account retrieval (5-14 accounts per request)
 • getProgramAccounts: Test program account enumeration
+• getVoteAccounts: Test cluster vote account listing (no accounts needed)
+• getClusterNodes: Test cluster node listing (no accounts needed)
+• getLargestAccounts: Test the heavy, often cached largest-accounts query (no accounts needed)
+• getSupply: Test token supply retrieval (no accounts needed)
+• getStakeActivation: Test stake account activation state lookups
+• getInflationReward: Test batched staking reward lookups (5-14 addresses per request)
+• getFeeForMessage: Test per-transaction fee estimation (no accounts needed)
+• raw: Test any other method with raw JSON params (no accounts needed)
 
 Examples:
   # Run comprehensive test suite (recommended)
@@ -40,13 +51,75 @@ Examples:
 }
 
 func init() {
+	cobra.OnInitialize(setupLogging, applyEnvDefaults, validateLatencyUnit, validatePrecision, validateRetries)
+
 	// Common flags for all commands
-	RootCmd.PersistentFlags().StringVarP(&rpcURL, "url", "u", "https://api.mainnet-beta.solana.com", "RPC endpoint URL")
+	RootCmd.PersistentFlags().StringVarP(&rpcURL, "url", "u", "https://api.mainnet-beta.solana.com", "RPC endpoint URL (http(s)://host or unix:///path/to/rpc.sock)")
 	RootCmd.PersistentFlags().IntVarP(&concurrency, "concurrency", "c", 1, "Number of concurrent requests")
+	RootCmd.PersistentFlags().IntVar(&slaThreshold, "sla-threshold", 0, "Report the percent of successful requests completing within this many milliseconds (0 disables)")
+	RootCmd.PersistentFlags().IntVar(&slaLatency, "sla-latency", 0, "Adapt concurrency to hold the p95 latency at this many milliseconds, starting from --concurrency (0 keeps concurrency fixed; single-method commands only)")
 	RootCmd.PersistentFlags().IntVarP(&duration, "duration", "d", 10, "Test duration in seconds")
 	RootCmd.PersistentFlags().StringArrayVarP(&accounts, "account", "a", []string{}, "Account addresses to use in tests (can be specified multiple times)")
+	RootCmd.PersistentFlags().Var(&accountList, "accounts", "Comma separated account addresses to use in tests, added to --account and --account-file")
 	RootCmd.PersistentFlags().StringVarP(&accountsFile, "account-file", "f", "", "File containing account addresses (one per line)")
+	RootCmd.PersistentFlags().StringVar(&dataDir, "data-dir", defaultDataDir(), "Directory for seeded accounts and other generated files ($XDG_DATA_HOME/rpc_test by default when XDG_DATA_HOME is set)")
 	RootCmd.PersistentFlags().IntVarP(&limit, "limit", "l", 0, "Limit the number of accounts/programs to process (0 for no limit)")
+	RootCmd.PersistentFlags().IntVar(&accountOffset, "account-offset", 0, "Skip this many accounts/programs before --limit takes its window, e.g. to give two runs disjoint slices")
+	RootCmd.PersistentFlags().StringVar(&protocol, "protocol", "auto", "HTTP protocol for the target RPC: auto, http1, http2 or both (compare http1 vs http2)")
+	RootCmd.PersistentFlags().StringVar(&compression, "compression", "gzip", "Accept-Encoding for the target RPC: gzip, none or both (compare with and without gzip)")
+	RootCmd.PersistentFlags().StringVar(&proxyAddr, "proxy", "", "Proxy URL for the target RPC (http://, https:// or socks5://, credentials allowed)")
+	RootCmd.PersistentFlags().StringVar(&errorLogPath, "error-log", "", "Append every failed request to this file as JSON lines (time, method, accounts, kind, code, message)")
+	RootCmd.PersistentFlags().IntVar(&errorLogMaxMB, "error-log-max-size", 100, "Rotate --error-log to <file>.1 once it reaches this many MB, keeping one previous file")
+	RootCmd.PersistentFlags().IntVar(&maxPayloadLog, "max-payload-log", 64<<10, "Truncate each --error-log message to this many bytes, noting the cut (0 logs messages whole)")
+	RootCmd.PersistentFlags().StringVar(&otelEndpoint, "otel-endpoint", "", "OTLP/HTTP collector URL to export one span per request to (e.g. http://localhost:4318)")
+	RootCmd.PersistentFlags().StringVar(&userAgent, "user-agent", methods.DefaultUserAgent, "Base User-Agent sent to the target RPC (the RPC method is appended)")
+	RootCmd.PersistentFlags().DurationVar(&requestTimeout, "timeout", 0, "Per-request timeout for the target RPC, including reading the response (0 keeps the 5m client default)")
+	RootCmd.PersistentFlags().IntVar(&connections, "connections", 0, "Bound the client to exactly this many connections to the target, independent of --concurrency (0 keeps the default pool of 9)")
+	RootCmd.PersistentFlags().BoolVar(&reconnectOnError, "reconnect-on-error", false, "Close the pooled idle connections after a connection reset or similar error so the next requests dial fresh ones, counting forced reconnects")
+	RootCmd.PersistentFlags().DurationVar(&connectTimeout, "connect-timeout", 0, "Timeout for establishing a connection to the target RPC, separate from --timeout (0 keeps the 5m dialer default)")
+	RootCmd.PersistentFlags().IntVar(&breakerThreshold, "breaker-threshold", 0, "Consecutive transport failures that open the endpoint's circuit breaker (0 disables the breaker)")
+	RootCmd.PersistentFlags().DurationVar(&breakerCooldown, "breaker-cooldown", 5*time.Second, "How long an open circuit breaker stops traffic before probing the endpoint again")
+	RootCmd.PersistentFlags().DurationVar(&progressInterval, "progress-interval", 0, "Progress display refresh interval, floored at 100ms (default 1s for single methods, 2s for runall; 0 disables progress output)")
+	RootCmd.PersistentFlags().BoolVar(&progressJSON, "progress-json", false, "Write progress as one JSON object per method and update to stderr instead of drawing progress bars")
+	RootCmd.PersistentFlags().StringVar(&resultsOutput, "output", "", "Save results as JSON to this file (for diff and aggregate)")
+	RootCmd.PersistentFlags().BoolVar(&resultsAppend, "output-append", false, "Append each run to --output as one JSON line (NDJSON) instead of overwriting it")
+	RootCmd.PersistentFlags().StringVar(&logFormat, "log-format", logFormatPretty, "Output format: pretty (decorated console output) or json (structured lifecycle events, one per line)")
+	RootCmd.PersistentFlags().BoolVar(&soakMode, "soak", false, "Soak test: periodically report the tool's heap and goroutines with rolling RPS/p95, flag steady growth (--duration defaults to 1h)")
+	RootCmd.PersistentFlags().DurationVar(&soakInterval, "soak-interval", time.Minute, "How often --soak takes a sample")
+	RootCmd.PersistentFlags().BoolVar(&crossCheckPerf, "cross-check-perf", false, "Print the node's self-reported performance samples from before and after the run")
+	RootCmd.PersistentFlags().IntVar(&shardIndex, "shard-index", 0, "Index of this machine's shard of the account list, from 0 to --shard-count - 1")
+	RootCmd.PersistentFlags().IntVar(&shardCount, "shard-count", 1, "Number of disjoint shards to split the account list into for split test runs")
+	RootCmd.PersistentFlags().Float64Var(&hotFraction, "hot-fraction", 0, "Fraction of accounts forming the hot set, e.g. 0.1 for the first 10% (0 disables weighting)")
+	RootCmd.PersistentFlags().BoolVar(&uniquePerWorker, "unique-per-worker", false, "Give each worker a disjoint range of the accounts to rotate through, falling back to shared rotation with fewer accounts than --concurrency")
+	RootCmd.PersistentFlags().StringVar(&latencyUnit, "latency-unit", latencyUnitAuto, "Unit of every latency in the report: auto (per value), us, ms or s")
+	RootCmd.PersistentFlags().BoolVar(&trackResources, "resources", false, "Sample the load generator's CPU, memory, goroutines and open files every second and report the peaks")
+	RootCmd.PersistentFlags().BoolVar(&hostResources, "host-resources", false, "Also sample the CPU of the whole host under --resources")
+	RootCmd.PersistentFlags().Float64Var(&cpuThreshold, "cpu-threshold", 90, "Percent of all cores above which --resources flags the load generator as the bottleneck")
+	RootCmd.PersistentFlags().IntVar(&slowestCount, "slowest", 0, "Report the N slowest requests of the run with their method, account and time (0 disables)")
+	RootCmd.PersistentFlags().StringVar(&recordPath, "record", "", "Write every issued request (method, arguments, time offset and error) to this NDJSON file for --replay")
+	RootCmd.PersistentFlags().StringVar(&replayPath, "replay", "", "Re-issue the requests of a --record file against --url in the recorded order instead of running a load test")
+	RootCmd.PersistentFlags().BoolVar(&replayTiming, "replay-timing", false, "Issue --replay requests at their recorded times instead of one after the other")
+	RootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Run without asking when the load on a mainnet endpoint is over --confirm-threshold, required without a terminal")
+	RootCmd.PersistentFlags().IntVar(&confirmThreshold, "confirm-threshold", 10000, "Worker-seconds (concurrency × duration, summed over methods) above which a mainnet run asks for confirmation (0 never asks)")
+	RootCmd.PersistentFlags().IntVar(&precision, "precision", 2, "Decimal places of latencies, rates and percentages in the report, 0 to 6")
+	RootCmd.PersistentFlags().BoolVar(&adaptiveRate, "adaptive-rate", false, "Pace requests and halve the rate on HTTP 429 (honoring Retry-After), growing it otherwise, to find the endpoint's allowed rate")
+	RootCmd.PersistentFlags().Float64Var(&adaptiveRateStart, "adaptive-rate-start", 100, "Requests per second --adaptive-rate starts from, it grows by a tenth of this each second without a 429")
+	RootCmd.PersistentFlags().StringVar(&slotFlag, "slot", "", "Read account state from at least this slot via minContextSlot, or latest to resolve it once at start, for consistent comparisons")
+	RootCmd.PersistentFlags().BoolVar(&trackSlot, "track-slot", false, "Measure how many slots behind the chain tip getAccountInfo and getMultipleAccounts responses are")
+	RootCmd.PersistentFlags().Uint64Var(&maxSlotLag, "max-slot-lag", 10, "Slot lag above which a response counts as stale under --track-slot")
+	RootCmd.PersistentFlags().StringVar(&slotReference, "slot-reference", "", "Endpoint whose finalized slot is the chain tip for --track-slot (default: the target itself)")
+	RootCmd.PersistentFlags().BoolVar(&strictMode, "strict", false, "Check that account method responses have the reference RPC's shape, reporting every violation and failing the run if any occurred")
+	RootCmd.PersistentFlags().Float64Var(&requireNonNull, "require-nonnull-ratio", 0, "Count a getMultipleAccounts request as failed when fewer than this percent of its accounts came back non-null (0 accepts any)")
+	RootCmd.PersistentFlags().BoolVar(&validateData, "validate-data", false, "Check that getMultipleAccounts account data decodes, counting failures as decode errors")
+	RootCmd.PersistentFlags().BoolVar(&waitForReady, "wait-for-ready", false, "Poll the target's getHealth (or getSlot) with backoff until it is serving before the test starts")
+	RootCmd.PersistentFlags().DurationVar(&waitTimeout, "wait-timeout", 2*time.Minute, "How long --wait-for-ready waits before failing")
+	RootCmd.PersistentFlags().IntVar(&retries, "retries", 0, "Re-send a request that failed in transport (connection errors, timeouts, rate limiting) up to this many times, 0 to 5")
+	RootCmd.PersistentFlags().DurationVar(&retryBackoff, "retry-backoff", 100*time.Millisecond, "Backoff window before the first retry, doubled for each further retry up to 5s")
+	RootCmd.PersistentFlags().BoolVar(&retryJitter, "retry-jitter", true, "Wait a random time within each retry's backoff window (full jitter), --retry-jitter=false for fixed exponential delays")
+	RootCmd.PersistentFlags().IntVar(&batchPoolSize, "batch-pool", 1024, "Number of getMultipleAccounts/getInflationReward batches precomputed before the run (0 builds each batch per request)")
+	RootCmd.PersistentFlags().DurationVar(&cacheHitThreshold, "warn-on-cache-hit", time.Millisecond, "Warn when an account method's p50 latency is below this with fixed account access, a sign of cache-served responses (0 disables)")
+	RootCmd.PersistentFlags().Float64Var(&hotRatio, "hot-ratio", 0.8, "Fraction of requests sent to the hot set when --hot-fraction is set")
+	RootCmd.PersistentFlags().BoolVar(&forceHTTP2, "http2", false, "Force HTTP/2 to the target RPC (shorthand for --protocol http2)")
 }
 
 // Execute adds all child commands to the root command and executes it
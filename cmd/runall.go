@@ -1,46 +1,77 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
-	"math/rand"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"rpc_test/methods"
 
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/spf13/cobra"
 )
 
 // TestConfig represents the configuration for the test
 type TestConfig struct {
-	RemoteRPCURL string   `json:"rpc_url"`
-	RPCAPIKey    string   `json:"rpc_apikey"`
-	Programs     []string `json:"programs"`
+	RemoteRPCURL string                 `json:"rpc_url"`
+	RPCAPIKey    string                 `json:"rpc_apikey"`
+	Programs     []string               `json:"programs"`
+	ProgramInfo  map[string]ProgramInfo `json:"program_info,omitempty"` // per-program filters keyed by program address
 }
 
 // ProgramInfo represents program-specific configuration
 type ProgramInfo struct {
-	Discriminator int      `json:"discriminator"`
-	Filters       []string `json:"filters"`
+	Discriminator     uint64   `json:"discriminator"`                // matched little-endian at offset 0 of the account data
+	DiscriminatorSize int      `json:"discriminator_size,omitempty"` // bytes of the discriminator, 8 (anchor) when unset
+	Filters           []string `json:"filters"`
 }
 
 // TestResult represents the result of a single method test
 type TestResult struct {
-	MethodName     string
-	Duration       time.Duration
-	TotalRequests  int64
-	SuccessCount   int64
-	FailureCount   int64
-	RequestsPerSec float64
-	SuccessRate    float64
-	MinLatency     time.Duration
-	MaxLatency     time.Duration
-	AvgLatency     time.Duration
+	MethodName           string
+	Duration             time.Duration
+	TotalRequests        int64
+	SuccessCount         int64
+	EmptyCount           int64 // successful requests the RPC answered with null (missing account, unavailable fee)
+	PartialResponseCount int64 // successful batch requests that returned fewer non-null accounts than requested
+	CountedAccounts      int64 // accounts enumerated across successful --count-only or --stream-decode getProgramAccounts requests
+	FailureCount         int64
+	RequestsPerSec       float64
+	SuccessRate          float64
+	MinLatency           time.Duration
+	MaxLatency           time.Duration
+	AvgLatency           time.Duration
+	P50Latency           time.Duration
+	P95Latency           time.Duration
+	WireBytes            int64
+	DecodedBytes         int64
+	HotSetHits           int64 // weighted account picks that landed in the hot set
+	AccountPicks         int64 // weighted account picks, 0 when the hot set is disabled
+	ErrorKinds           map[string]int64
+	ErrorCodes           map[int]*RPCErrorCount
+	BreakerTrips         int64         // times the endpoint's circuit breaker opened during the test
+	SkippedByBreaker     int64         // requests not sent because the breaker was open
+	SLAConcurrency       float64       // steady-state workers under --sla-latency, 0 for fixed concurrency
+	SLARequestsPerSec    float64       // requests per second at the steady state under --sla-latency
+	SettledRate          float64       // requests per second --adaptive-rate settled at, 0 when it is off
+	SLACompliant         int64         // successful requests within --sla-threshold
+	AvgSlotLag           float64       // slots the responses trailed the chain tip on average under --track-slot
+	SlotLagSamples       int64         // successful responses whose slot lag was measured
+	StaleResponses       int64         // measured responses more than --max-slot-lag behind the tip
+	ConnectionsOpened    int64         // connections dialed to the target, tracked under --connections
+	ConnectionWaits      int64         // requests that stalled waiting for a free connection under --connections
+	ConnectionWaitTime   time.Duration // total time spent in those stalls
+	Reconnects           int64         // times --reconnect-on-error dropped the pooled connections
+	Retries              int64         // transport failures re-sent under --retries
+	Error                string        // why the method could not run at all, empty when it ran
 }
 
 // OverallResult represents the overall test results
@@ -52,12 +83,13 @@ type OverallResult struct {
 	OverallRPS         float64
 	OverallSuccessRate float64
 	MethodResults      []TestResult
+	Metadata           RunMetadata // how the run was produced, printed above the summary
 }
 
 // Default configuration as specified
 var defaultConfig = TestConfig{
 	RemoteRPCURL: "https://us.rpc.fluxbeam.xyz",
-	RPCAPIKey:    "YOUR_API_KEY_HERE",
+	RPCAPIKey:    methods.PlaceholderAPIKey,
 	Programs:     []string{"2wT8Yq49kHgDzXuPxZSaeLaH1qbmGXtEyPy64bL7aD3c"},
 }
 
@@ -66,6 +98,8 @@ type ProgressManager struct {
 	methods      map[string]*MethodProgress
 	mutex        sync.RWMutex
 	stopChan     chan struct{}
+	stopOnce     sync.Once     // Stop may be called from both the interrupt and the completion path
+	done         chan struct{} // closed when the display loop has returned
 	firstDisplay bool
 }
 
@@ -79,6 +113,11 @@ type MethodProgress struct {
 	TotalRequests   int64
 	RequestsPerSec  float64
 	PercentComplete float64
+
+	// CurrentRPS and CurrentLatency cover only the last few seconds, see liveWindow
+	CurrentRPS     float64
+	CurrentLatency time.Duration
+	window         liveWindow
 }
 
 // NewProgressManager creates a new progress manager
@@ -86,6 +125,7 @@ func NewProgressManager() *ProgressManager {
 	return &ProgressManager{
 		methods:      make(map[string]*MethodProgress),
 		stopChan:     make(chan struct{}),
+		done:         make(chan struct{}),
 		firstDisplay: true,
 	}
 }
@@ -106,7 +146,7 @@ func (pm *ProgressManager) RegisterMethod(methodName string, duration int) {
 }
 
 // UpdateProgress updates progress for a specific method
-func (pm *ProgressManager) UpdateProgress(methodName string, successCount, failureCount int64) {
+func (pm *ProgressManager) UpdateProgress(methodName string, successCount, failureCount int64, totalLatency time.Duration) {
 	pm.mutex.Lock()
 	defer pm.mutex.Unlock()
 
@@ -124,27 +164,28 @@ func (pm *ProgressManager) UpdateProgress(methodName string, successCount, failu
 		if method.PercentComplete > 100 {
 			method.PercentComplete = 100
 		}
+
+		snapshot := liveSnapshot{at: time.Now(), requests: method.TotalRequests, successes: successCount, totalLatency: totalLatency}
+		method.CurrentRPS, method.CurrentLatency = method.window.rates(snapshot)
+		method.window.add(snapshot)
 	}
 }
 
 // DisplayProgress displays all progress bars
 func (pm *ProgressManager) DisplayProgress() {
-	pm.mutex.RLock()
-	defer pm.mutex.RUnlock()
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
 
-	// Only clear and redraw if we've displayed before
+	// Only redraw over the previous display if there is one, each line below clears itself
 	if !pm.firstDisplay {
-		// Move up 3 lines and clear them
-		fmt.Print("\033[3A")
-		fmt.Print("\033[K\033[K\033[K")
+		// Move up one line per method
+		fmt.Fprintf(output, "\033[%dA", len(runallMethods))
 	} else {
 		pm.firstDisplay = false
 	}
 
 	// Display each method's progress
-	methodNames := []string{"getAccountInfo", "getMultipleAccounts", "getProgramAccounts"}
-
-	for _, methodName := range methodNames {
+	for _, methodName := range runallMethods {
 		if method, exists := pm.methods[methodName]; exists {
 			filledChar, emptyChar, icon := getProgressBarStyle(methodName)
 
@@ -154,38 +195,111 @@ func (pm *ProgressManager) DisplayProgress() {
 
 			elapsed := int(time.Since(method.StartTime).Seconds())
 
-			fmt.Printf("    %s [%s] %s: %.1f%% | %ds/%ds | Requests: %d | RPS: %.1f\n",
-				icon, progressBar, methodName, method.PercentComplete, elapsed, duration, method.TotalRequests, method.RequestsPerSec)
+			fmt.Fprintf(output, "\033[K    %s [%s] %s: %.1f%% | %ds/%ds | Requests: %d | RPS: %.1f now, %.1f avg | Latency: %s now\n",
+				icon, progressBar, methodName, method.PercentComplete, elapsed, duration, method.TotalRequests, method.CurrentRPS, method.RequestsPerSec, formatLatency(method.CurrentLatency))
 		} else {
 			// Method not started yet
 			_, emptyChar, icon := getProgressBarStyle(methodName)
 			progressBar := strings.Repeat(emptyChar, 20)
-			fmt.Printf("    %s [%s] %s: 0.0%% | 0s/%ds | Requests: 0 | RPS: 0.0\n",
+			fmt.Fprintf(output, "\033[K    %s [%s] %s: 0.0%% | 0s/%ds | Requests: 0 | RPS: 0.0 now, 0.0 avg | Latency: - now\n",
 				icon, progressBar, methodName, duration)
 		}
 	}
 }
 
+// EmitProgressJSON writes the progress of every started method as --progress-json lines
+func (pm *ProgressManager) EmitProgressJSON() {
+	pm.mutex.RLock()
+	defer pm.mutex.RUnlock()
+
+	for _, methodName := range runallMethods {
+		if method, exists := pm.methods[methodName]; exists {
+			emitProgressJSON(methodName, method.PercentComplete, method.SuccessCount, method.FailureCount, time.Since(method.StartTime))
+		}
+	}
+}
+
 // StartProgressDisplay starts the progress display loop
 func (pm *ProgressManager) StartProgressDisplay() {
-	ticker := time.NewTicker(2 * time.Second)
+	defer close(pm.done)
+
+	interval := progressRefresh(2 * time.Second)
+	if interval == 0 {
+		<-pm.stopChan
+		return
+	}
+
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			pm.DisplayProgress()
+			if progressJSON {
+				pm.EmitProgressJSON()
+			} else {
+				pm.DisplayProgress()
+			}
 		case <-pm.stopChan:
 			return
 		}
 	}
 }
 
-// Stop stops the progress display
+// Stop stops the progress display, further calls are no-ops
 func (pm *ProgressManager) Stop() {
-	close(pm.stopChan)
+	pm.stopOnce.Do(func() {
+		close(pm.stopChan)
+	})
+}
+
+// Wait blocks until the display loop started by StartProgressDisplay has returned
+func (pm *ProgressManager) Wait() {
+	<-pm.done
 }
 
+var (
+	// runallPrograms overrides config.Programs for the seeding step
+	runallPrograms []string
+
+	// runallSeedLimit is how many accounts to seed per program
+	runallSeedLimit int
+
+	// reuseAccounts skips seeding when the accounts file is younger than accountsTTL
+	reuseAccounts bool
+	accountsTTL   time.Duration
+
+	// noSeed tests the --account-file as is, without seeding
+	noSeed bool
+
+	// sequentialSuite runs the suite's methods one after another instead of all at once
+	sequentialSuite bool
+
+	// summaryOnly silences the decorated output and prints just one overall summary line
+	summaryOnly bool
+
+	// regenConfig regenerates config.json even when it exists
+	regenConfig bool
+
+	// methodOrder runs the listed suite methods first, one after another in this order
+	methodOrder []string
+
+	// disabledMethods are suite methods left out of the run
+	disabledMethods []string
+
+	// cooldown is the pause in seconds between sequential methods
+	cooldown int
+
+	// cooldownPing sends light getSlot requests during --cooldown instead of leaving the endpoint idle
+	cooldownPing bool
+)
+
+// Values of RunMetadata.Mode for the method suite
+const (
+	suiteModeConcurrent = "concurrent"
+	suiteModeSequential = "sequential"
+)
+
 // runallCmd represents the runall command
 var runallCmd = &cobra.Command{
 	Use:   "runall",
@@ -193,8 +307,8 @@ var runallCmd = &cobra.Command{
 	Long: `Execute a comprehensive test suite that includes:
 
 1. Configuration Generation: Creates test configuration with your API key
-2. Data Directory Setup: Creates ./data/ directory for storing test files  
-3. Account Seeding: Seeds 100 accounts from specified program using remote RPC
+2. Data Directory Setup: Creates the --data-dir directory (./data) for storing test files
+3. Account Seeding: Seeds 100 accounts (--seed-limit) from specified program using remote RPC
 4. Method Testing: Runs all available RPC methods concurrently against target RPC
 5. Progress Tracking: Real-time progress bars with live statistics
 6. Comprehensive Results: Detailed performance metrics for each method and overall summary
@@ -217,66 +331,296 @@ Examples:
   # Advanced test with custom settings
   rpc_test runall --api-key YOUR_API_KEY --url https://your-target-rpc.com --concurrency 10 --duration 30 --limit 200
   
+  # Seed from a different program without editing config.json
+  rpc_test runall --api-key YOUR_API_KEY --url https://your-target-rpc.com --program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA
+
+  # Test a fixed, curated account set without seeding
+  rpc_test runall --url https://your-target-rpc.com --no-seed --account-file ./curated_accounts.txt
+
+  # Iterate locally without re-seeding on every run
+  rpc_test runall --api-key YOUR_API_KEY --url http://localhost:8080 --reuse-accounts --accounts-ttl 6h
+
   # Test against Lantern (common use case)
   rpc_test runall --api-key YOUR_FLUX_API_KEY --url http://localhost:8080`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("🚀 Starting comprehensive RPC test suite...")
-		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	// Errors are returned rather than exiting so the deferred profiler stop still writes complete profiles
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if slaLatency > 0 {
+			return fmt.Errorf("--sla-latency is not supported by runall, run a single method instead")
+		}
+		if probeWrite && !probeAccounts {
+			return fmt.Errorf("--probe-write needs --probe-accounts")
+		}
+		if len(disabledMethods) > 0 {
+			enabled, err := enabledSuite(runallMethods, disabledMethods)
+			if err != nil {
+				return err
+			}
+			runallMethods = enabled
+			fmt.Fprintf(output, "📋 Methods: %s (disabled: %s)\n", strings.Join(runallMethods, ", "), strings.Join(disabledMethods, ", "))
+		}
+		if len(methodOrder) > 0 {
+			ordered, err := orderedSuite(runallMethods, methodOrder)
+			if err != nil {
+				return err
+			}
+			runallMethods = ordered
+			sequentialSuite = true
+		}
+		if cooldown < 0 {
+			return fmt.Errorf("--cooldown must be at least 0, got %d", cooldown)
+		}
+		if cooldown > 0 && !sequentialSuite {
+			return fmt.Errorf("--cooldown needs --sequential or --order, concurrent methods have nothing to pause between")
+		}
+		if cooldownPing && cooldown == 0 {
+			return fmt.Errorf("--cooldown-ping needs --cooldown")
+		}
+		if replayPath != "" {
+			resolveProtocol()
+			resolveCompression()
+			resolveProxy()
+			runReplay(rpcURL, "")
+			return nil
+		}
+
+		// Flags that would exit are checked before the profiler starts, later failures return errors
+		// so the deferred stop still writes the profiles
+		if rpcURL == "" || rpcURL == "https://api.mainnet-beta.solana.com" {
+			return fmt.Errorf("--url is required: the target RPC endpoint to test, e.g. --url https://your-target-rpc.com")
+		}
+		resolveProtocol()
+		resolveCompression()
+		resolveProxy()
+		if protocol == protocolBoth || compression == compressionBoth {
+			return fmt.Errorf("comparison modes (both) are only supported by the individual method commands")
+		}
+		if err := validateShard(); err != nil {
+			return err
+		}
+		if err := validateHotSet(); err != nil {
+			return err
+		}
+		if err := validateCallOptions(); err != nil {
+			return err
+		}
+		workers, seconds := suiteLoad(concurrency, plannedDuration(cmd.Flags().Changed("duration")), len(runallMethods))
+		confirmLoad(rpcURL, workers, seconds)
+
+		stopProfiling := startProfiling()
+		defer stopProfiling()
+
+		var summaryOut io.Writer
+		if summaryOnly {
+			summaryOut = silenceOutput()
+		}
+
+		fmt.Fprintln(output, "🚀 Starting comprehensive RPC test suite...")
+		fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 		// Step 1: Generate and save test configuration
 		var config TestConfig
 
 		//check if config.json exists
-		if _, err := os.Stat("./config.json"); err == nil {
-			fmt.Println("📋 Step 1: Loading existing test configuration...")
+		_, statErr := os.Stat("./config.json")
+		if statErr == nil && !regenConfig {
+			fmt.Fprintln(output, "📋 Step 1: Loading existing test configuration...")
 			showProgress("Loading config", 100)
+			var err error
 			config, err = loadTestConfig("./config.json")
 			if err != nil {
-				log.Fatalf("Failed to load test config: %v", err)
+				return fmt.Errorf("failed to load test config: %v", err)
 			}
 			showProgressComplete("Config loaded")
-			fmt.Printf("✅ Configuration loaded successfully\n")
+			fmt.Fprintf(output, "✅ Configuration loaded successfully\n")
 		} else {
-			fmt.Println("📋 Step 1: Generating test configuration...")
-			showProgress("Generating config", 100)
 			configFile := "./config.json"
-			if err := generateTestConfig(configFile); err != nil {
-				log.Fatalf("Failed to generate test config: %v", err)
+
+			// --regen-config keeps the existing API key unless --api-key replaces it
+			var previous *TestConfig
+			if statErr == nil {
+				fmt.Fprintln(output, "📋 Step 1: Regenerating test configuration (--regen-config)...")
+				if existing, err := loadTestConfig(configFile); err == nil {
+					previous = &existing
+				} else {
+					fmt.Fprintf(output, "⚠️  Could not read the existing config, its API key is not kept: %v\n", err)
+				}
+			} else {
+				fmt.Fprintln(output, "📋 Step 1: Generating test configuration...")
+			}
+			showProgress("Generating config", 100)
+			if err := generateTestConfig(configFile, previous); err != nil {
+				return fmt.Errorf("failed to generate test config: %v", err)
 			}
 			showProgressComplete("Config generated")
-			fmt.Printf("✅ Test configuration saved to: %s\n", configFile)
+			if statErr == nil {
+				fmt.Fprintf(output, "✅ Test configuration regenerated at: %s\n", configFile)
+			} else {
+				fmt.Fprintf(output, "✅ Test configuration saved to: %s\n", configFile)
+			}
+			var err error
 			config, err = loadTestConfig(configFile)
 			if err != nil {
-				log.Fatalf("Failed to load test config: %v", err)
+				return fmt.Errorf("failed to load test config: %v", err)
 			}
 			showProgressComplete("Config loaded")
-			fmt.Printf("✅ Configuration loaded successfully\n")
+			fmt.Fprintf(output, "✅ Configuration loaded successfully\n")
+		}
+
+		logConfigLoaded("runall")
+		if err := resolveProgramFilters(config.ProgramInfo); err != nil {
+			return err
+		}
+
+		// --program overrides the configured programs for this run only
+		if len(runallPrograms) > 0 {
+			for _, program := range runallPrograms {
+				if _, err := solana.PublicKeyFromBase58(program); err != nil {
+					return fmt.Errorf("invalid --program %s: %v", program, err)
+				}
+			}
+			config.Programs = runallPrograms
+			fmt.Fprintf(output, "📌 Seeding from --program instead of config: %s\n", strings.Join(runallPrograms, ", "))
 		}
+		suitePrograms = config.Programs
 
-		// Step 2: Seed accounts from the program
-		fmt.Println("\n🌱 Step 2: Seeding accounts from program...")
-		accountsFile := "./data/test_accounts.txt"
-		if err := seedAccountsFromProgram(accountsFile, config); err != nil {
-			log.Fatalf("Failed to seed accounts: %v", err)
+		// Step 2: Seed accounts from the program. --program seeds a file of its own, so accounts seeded from
+		// the config's programs by earlier runs are not tested with it
+		accountsFile := dataPath("test_accounts.txt")
+		if len(runallPrograms) > 0 {
+			accountsFile = dataPath(programAccountsFile)
+		}
+		if noSeed {
+			var err error
+			if accountsFile, err = preseededAccountsFile(); err != nil {
+				return err
+			}
+			fmt.Fprintln(output, "\n📂 Step 2: Using pre-seeded accounts (--no-seed)...")
+			fmt.Fprintf(output, "✅ Using %d accounts from %s\n", countSeededAccounts(accountsFile), accountsFile)
+			logEvent("seeding_skipped", "output", accountsFile, "no_seed", true)
+		} else if age, ok := reusableAccounts(accountsFile); ok {
+			fmt.Fprintln(output, "\n♻️  Step 2: Reusing seeded accounts...")
+			fmt.Fprintf(output, "✅ Reused %d accounts from %s (seeded %s ago)\n", countSeededAccounts(accountsFile), accountsFile, age.Round(time.Second))
+			logEvent("seeding_skipped", "output", accountsFile, "age_s", age.Seconds())
+		} else {
+			fmt.Fprintln(output, "\n🌱 Step 2: Seeding accounts from program...")
+			noKey := methods.IsPlaceholderAPIKey(config.RPCAPIKey)
+			if noKey {
+				fmt.Fprintf(output, "⚠️  No API key set, seeding from %s without one\n", redactURL(config.RemoteRPCURL))
+			}
+			logEvent("seeding_started", "remote_url", redactURL(config.RemoteRPCURL), "output", accountsFile)
+			if len(runallPrograms) > 0 {
+				if err := os.Remove(accountsFile); err != nil && !os.IsNotExist(err) {
+					return fmt.Errorf("failed to clear %s: %v", accountsFile, err)
+				}
+			}
+			seedStart := time.Now()
+			if err := seedAccountsFromProgram(accountsFile, config, runallSeedLimit); err != nil {
+				if noKey {
+					return fmt.Errorf("failed to seed accounts: %v\nNo API key is set: set your API key via --api-key or rpc_apikey in ./config.json", err)
+				}
+				return fmt.Errorf("failed to seed accounts: %v", err)
+			}
+			seedDuration := time.Since(seedStart)
+			fmt.Fprintf(output, "✅ Accounts freshly seeded to: %s in %s\n", accountsFile, seedDuration.Round(time.Millisecond))
+			logEvent("seeding_finished", "output", accountsFile, "duration_s", seedDuration.Seconds())
 		}
-		fmt.Printf("✅ Accounts seeded to: %s\n", accountsFile)
 
 		// Step 3: Run all methods
-		fmt.Println("\n⚡ Step 3: Running all RPC methods...")
-		results, err := runAllMethods(accountsFile)
+		fmt.Fprintln(output, "\n⚡ Step 3: Running all RPC methods...")
+		var perfBefore *rpc.GetRecentPerformanceSamplesResult
+		if crossCheckPerf {
+			perfBefore = capturePerfSample("before")
+		}
+
+		startConformance()
+		startSlowest()
+		if err := startRunMonitors(cmd.Flags().Changed("duration")); err != nil {
+			return err
+		}
+		results, accountCount, err := runAllMethods(accountsFile)
+		stopRunMonitors()
 		if err != nil {
-			log.Fatalf("Failed to run methods: %v", err)
+			return fmt.Errorf("failed to run methods: %v", err)
 		}
 
 		// Step 4: Generate and display statistics
-		fmt.Println("\n📊 Step 4: Generating comprehensive statistics...")
+		fmt.Fprintln(output, "\n📊 Step 4: Generating comprehensive statistics...")
 		showProgress("Calculating statistics", 100)
 		overallResult := calculateOverallResults(results)
+		overallResult.Metadata = newRunMetadata("runall", runallMethods, accountCount)
+		overallResult.Metadata.Mode = suiteMode()
+		overallResult.Metadata.CooldownSecs, overallResult.Metadata.CooldownPing = cooldown, cooldownPing
+		logEvent("run_finished",
+			"methods", len(results),
+			"total_requests", overallResult.TotalRequests,
+			"total_success", overallResult.TotalSuccess,
+			"total_failure", overallResult.TotalFailure,
+			"overall_rps", overallResult.OverallRPS,
+			"overall_success_rate", overallResult.OverallSuccessRate,
+		)
 		showProgressComplete("Statistics calculated")
-		displayResults(results, overallResult)
+		if summaryOnly {
+			printSummaryLine(summaryOut, overallResult)
+		} else {
+			displayResults(results, overallResult)
+			printSlowest()
+			printResources()
+		}
+		saveResults(overallResult.Metadata, results)
+
+		if crossCheckPerf {
+			printPerfCrossCheck(perfBefore, capturePerfSample("after"), results)
+		}
+		return finishConformance()
 	},
 }
 
+// startRunMonitors starts the optional tracing, error log, recording and samplers of a run, stopping the
+// ones already started when another fails
+func startRunMonitors(durationSet bool) error {
+	starts := []func() error{startTracing, startErrorLog, startRecording, func() error { return startSoak(durationSet) }, startResources}
+	for _, start := range starts {
+		if err := start(); err != nil {
+			stopRunMonitors()
+			return err
+		}
+	}
+	return nil
+}
+
+// stopRunMonitors stops what startRunMonitors started, in reverse order
+func stopRunMonitors() {
+	stopResources()
+	stopSoak()
+	stopRecording()
+	stopErrorLog()
+	stopTracing()
+}
+
+// silenceOutput discards the decorated output, as JSON logging does, and returns the writer it went to for
+// the --summary-only line
+func silenceOutput() io.Writer {
+	summaryOut := output
+	setOutput(io.Discard)
+	return summaryOut
+}
+
+// printSummaryLine prints the overall results as one line of key=value pairs for shell pipelines
+func printSummaryLine(w io.Writer, overall OverallResult) {
+	fmt.Fprintf(w, "overall_rps=%.2f success_rate=%.2f total_requests=%d total_success=%d total_failure=%d duration_s=%.2f",
+		overall.OverallRPS, overall.OverallSuccessRate, overall.TotalRequests, overall.TotalSuccess, overall.TotalFailure, overall.TotalDuration.Seconds())
+	if slaThreshold > 0 && overall.TotalSuccess > 0 {
+		var compliant int64
+		for _, result := range overall.MethodResults {
+			compliant += result.SLACompliant
+		}
+		fmt.Fprintf(w, " sla_compliance=%.2f", float64(compliant)/float64(overall.TotalSuccess)*100)
+	}
+	fmt.Fprintln(w)
+}
+
 // getProgressBarStyle returns different progress bar styles for different methods
 func getProgressBarStyle(methodName string) (string, string, string) {
 	switch methodName {
@@ -296,18 +640,19 @@ func showProgress(message string, percentage int) {
 	const barWidth = 30
 	progress := int(float64(percentage) * float64(barWidth) / 100)
 	progressBar := strings.Repeat("█", progress) + strings.Repeat("░", barWidth-progress)
-	fmt.Printf("\r[%s] %s... %d%%", progressBar, message, percentage)
+	fmt.Fprintf(output, "\r[%s] %s... %d%%", progressBar, message, percentage)
 }
 
 // showProgressComplete displays a completed progress bar
 func showProgressComplete(message string) {
 	const barWidth = 30
 	progressBar := strings.Repeat("█", barWidth)
-	fmt.Printf("\r[%s] %s... ✅\n", progressBar, message)
+	fmt.Fprintf(output, "\r[%s] %s... ✅\n", progressBar, message)
 }
 
-// generateTestConfig creates and saves the test configuration
-func generateTestConfig(configFile string) error {
+// generateTestConfig creates and saves the test configuration, keeping the API key of previous when
+// --api-key is empty
+func generateTestConfig(configFile string, previous *TestConfig) error {
 	// Create data directory if it doesn't exist
 	dataDir := filepath.Dir(configFile)
 	if err := os.MkdirAll(dataDir, 0755); err != nil {
@@ -320,12 +665,15 @@ func generateTestConfig(configFile string) error {
 	// Use provided API key if available
 	if apiKey != "" {
 		config.RPCAPIKey = apiKey
-		fmt.Printf("✅ Using provided API key: %s...\n", apiKey[:8]+"***")
+		fmt.Fprintf(output, "✅ Using provided API key: %s...\n", apiKey[:8]+"***")
+	} else if previous != nil && !methods.IsPlaceholderAPIKey(previous.RPCAPIKey) {
+		config.RPCAPIKey = previous.RPCAPIKey
+		fmt.Fprintln(output, "✅ Keeping the API key of the existing config")
 	} else {
-		fmt.Println("⚠️  WARNING: No API key provided!")
-		fmt.Println("   Please edit the generated config file to set your API key:")
-		fmt.Printf("   %s\n", configFile)
-		fmt.Println("   Or use the --api-key flag to provide it directly.")
+		fmt.Fprintln(output, "⚠️  WARNING: No API key provided!")
+		fmt.Fprintln(output, "   Please edit the generated config file to set your API key:")
+		fmt.Fprintf(output, "   %s\n", configFile)
+		fmt.Fprintln(output, "   Or use the --api-key flag to provide it directly.")
 	}
 
 	// Marshal configuration to JSON
@@ -357,93 +705,158 @@ func loadTestConfig(configFile string) (TestConfig, error) {
 	return config, nil
 }
 
-// seedAccountsFromProgram seeds 100 accounts from the default program
-func seedAccountsFromProgram(accountsFile string, config TestConfig) error {
-	// Create data directory if it doesn't exist
-	dataDir := filepath.Dir(accountsFile)
-	if err := os.MkdirAll(dataDir, 0755); err != nil {
-		return fmt.Errorf("failed to create data directory: %v", err)
+// preseededAccountsFile returns the --account-file used by --no-seed, failing when it is missing or empty
+func preseededAccountsFile() (string, error) {
+	if accountsFile == "" {
+		return "", fmt.Errorf("--no-seed requires --account-file")
+	}
+	if _, err := os.Stat(accountsFile); err != nil {
+		return "", fmt.Errorf("failed to read --account-file: %v", err)
+	}
+	if countSeededAccounts(accountsFile) == 0 {
+		return "", fmt.Errorf("no accounts found in --account-file %s", accountsFile)
 	}
+	return accountsFile, nil
+}
+
+// programAccountsFile is the file in --data-dir that runall --program seeds afresh on every run
+const programAccountsFile = "test_accounts_program.txt"
 
-	// Get the program ID from default config
-	var programID string
-	for _, program := range config.Programs {
-		programID = program
-		break
+// reusableAccounts reports whether --reuse-accounts applies to accountsFile and how old the file is. It never
+// applies with --program, whose accounts are always seeded from the chosen programs
+func reusableAccounts(accountsFile string) (time.Duration, bool) {
+	if !reuseAccounts || len(runallPrograms) > 0 {
+		return 0, false
 	}
 
-	if programID == "" {
+	info, err := os.Stat(accountsFile)
+	if err != nil {
+		return 0, false
+	}
+
+	age := time.Since(info.ModTime())
+	if age > accountsTTL {
+		fmt.Fprintf(output, "\n⏰ %s is %s old, older than --accounts-ttl %s, re-seeding\n", accountsFile, age.Round(time.Second), accountsTTL)
+		return age, false
+	}
+	if countSeededAccounts(accountsFile) == 0 {
+		return age, false
+	}
+	return age, true
+}
+
+// seedAccountsFromProgram seeds up to seedLimit accounts from each configured program
+func seedAccountsFromProgram(accountsFile string, config TestConfig, seedLimit int) error {
+	if len(config.Programs) == 0 {
 		return fmt.Errorf("no program found in default configuration")
 	}
 
 	// Use the config RPC URL for seeding (remote RPC)
 	seedRPCURL := config.RemoteRPCURL
 
-	fmt.Printf("  🔍 Using remote RPC for seeding: %s\n", config.RemoteRPCURL)
-	fmt.Printf("  🔍 Fetching accounts from program %s...\n", programID[:8]+"...")
+	fmt.Fprintf(output, "  🔍 Using remote RPC for seeding: %s\n", config.RemoteRPCURL)
 
 	// Create RPC client for seeding (using config RPC URL)
 	rpcTest := methods.NewRPCTest(seedRPCURL, config.RPCAPIKey)
+	applyProgramFilters(rpcTest)
 
-	// Seed program accounts with limit of 100
-	err := rpcTest.SeedProgramAccounts(programID, accountsFile, 100)
-	if err != nil {
-		return err
+	seeded := 0
+	for _, programID := range config.Programs {
+		fmt.Fprintf(output, "  🔍 Fetching accounts from program %s...\n", programID[:8]+"...")
+
+		n, err := rpcTest.SeedProgramAccounts(programID, accountsFile, seedLimit)
+		if err != nil {
+			return err
+		}
+		seeded += n
+	}
+
+	// Every method would fail on an empty account set, so stop at the step that caused it. The file can still
+	// hold accounts from an earlier run, so count what this seed returned rather than what the file holds
+	if seeded == 0 {
+		return fmt.Errorf("seeding produced no accounts: %s returned none for %s; seed from another program with --program, "+
+			"or remove the program's filters (program_info in config.json, --programs-discriminator)",
+			redactURL(seedRPCURL), strings.Join(config.Programs, ", "))
 	}
 
 	// Show completion
-	fmt.Printf("  ✅ Successfully seeded accounts\n")
+	fmt.Fprintf(output, "  ✅ Successfully seeded accounts\n")
 	return nil
 }
 
-// runAllMethods runs all available RPC methods and returns results
-func runAllMethods(accountsFile string) ([]TestResult, error) {
-	// Check if --url flag is provided for target RPC
-	if rpcURL == "" || rpcURL == "https://api.mainnet-beta.solana.com" {
-		log.Fatalf("❌ ERROR: --url flag is required for target RPC testing!")
-		fmt.Println("   Please provide the target RPC endpoint using --url flag.")
-		fmt.Println("   Example: --url https://your-target-rpc.com")
-		fmt.Println("   This is the RPC endpoint you want to test/benchmark.")
-	}
-
-	fmt.Printf("  🎯 Using target RPC for testing: %s\n", rpcURL)
+// runallMethods are the RPC methods runall tests
+var runallMethods = methods.SuiteMethods()
 
-	// Define all available methods
-	methods := []string{"getAccountInfo", "getMultipleAccounts", "getProgramAccounts"}
+// runAllMethods runs all available RPC methods and returns results with the number of accounts tested
+func runAllMethods(accountsFile string) ([]TestResult, int, error) {
+	fmt.Fprintf(output, "  🎯 Using target RPC for testing: %s\n", rpcURL)
+	fmt.Fprintf(output, "  🌐 Protocol: %s\n", protocolLabel(protocol))
+	if targetProxy != nil {
+		fmt.Fprintf(output, "  🛡️  Proxy: %s\n", proxyLabel())
+	}
 
 	// Load accounts from file
-	data, err := os.ReadFile(accountsFile)
+	accounts, err := methods.ReadAccountFile(accountsFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read accounts file: %v", err)
+		return nil, 0, fmt.Errorf("failed to read accounts file: %v", err)
 	}
 
-	lines := strings.Split(string(data), "\n")
-	var accounts []string
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			accounts = append(accounts, line)
-		}
+	if len(accounts) == 0 {
+		return nil, 0, fmt.Errorf("no accounts found in file")
+	}
+	if accounts, err = pruneDeadAccounts(accounts, accountsFile); err != nil {
+		return nil, 0, err
 	}
 
+	// Keep only this machine's shard before applying the limit
+	accounts = shardAccounts(accounts)
 	if len(accounts) == 0 {
-		return nil, fmt.Errorf("no accounts found in file")
+		return nil, 0, fmt.Errorf("shard %d/%d has no accounts", shardIndex, shardCount)
 	}
 
-	// Apply limit if specified
-	if limit > 0 && limit < len(accounts) {
-		accounts = accounts[:limit]
+	// Apply the offset and limit, per method when --method-offset gives the methods their own windows
+	if len(methodOffsets) == 0 {
+		window, err := accountWindow(accounts, accountOffset, limit)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid --account-offset: %v", err)
+		}
+		accounts = window
+	} else if err := resolveMethodOffsets(accounts); err != nil {
+		return nil, 0, err
 	}
 
-	fmt.Printf("  📊 Testing %d methods with %d accounts\n", len(methods), len(accounts))
-	fmt.Printf("  ⚙️  Concurrency: %d, Duration: %ds per method\n", concurrency, duration)
+	fmt.Fprintf(output, "  📊 Testing %d methods with %d accounts\n", len(runallMethods), len(accounts))
+	if hotSize := hotSetSize(len(accounts)); hotSize > 0 {
+		fmt.Fprintf(output, "  🔥 Hot set: %.0f%% of requests target the hottest %d accounts\n", hotRatio*100, hotSize)
+	}
+	if workers := disjointWorkers(len(accounts)); workers > 0 {
+		fmt.Fprintf(output, "  🧩 Unique per worker: each worker reads its own %d accounts\n", len(accounts)/workers)
+	}
+	if warning := accountSharingWarning(len(accounts)); warning != "" {
+		fmt.Fprintf(output, "  ⚠️  %s\n", warning)
+	}
+	fmt.Fprintf(output, "  ⚙️  Concurrency: %d, Duration: %ds per method\n", concurrency, duration)
+	fmt.Fprintf(output, "  🔀 Mode: %s\n", suiteModeLabel())
+
+	if err := waitUntilReady(rpcURL); err != nil {
+		return nil, 0, err
+	}
+	if err := resolveSlot(rpcURL); err != nil {
+		return nil, 0, err
+	}
+	return runMethodSuite(rpcURL, accounts), len(accounts), nil
+}
 
+// runMethodSuite runs every runall method concurrently against targetURL with a live progress display
+func runMethodSuite(targetURL string, accounts []string) []TestResult {
 	// Create progress manager
 	progressManager := NewProgressManager()
 
-	// Register all methods
-	for _, methodName := range methods {
-		progressManager.RegisterMethod(methodName, duration)
+	// Register all methods, sequential runs register each as it starts so the rest show as not started
+	if !sequentialSuite {
+		for _, methodName := range runallMethods {
+			progressManager.RegisterMethod(methodName, duration)
+		}
 	}
 
 	// Start progress display in background
@@ -456,175 +869,195 @@ func runAllMethods(accountsFile string) ([]TestResult, error) {
 	var wg sync.WaitGroup
 	var mutex sync.Mutex
 
-	// Run each method concurrently
-	for i, methodName := range methods {
-		wg.Add(1)
-		go func(method string, methodIndex int) {
-			defer wg.Done()
+	if sequentialSuite {
+		// Run each method alone, so no method contends with another for the connections
+		for i, methodName := range runallMethods {
+			if i > 0 {
+				coolDown(targetURL)
+			}
+			progressManager.RegisterMethod(methodName, duration)
+			results = append(results, runSingleMethod(targetURL, methodName, suiteTargets(methodName, accounts), i+1, len(runallMethods), progressManager))
+		}
+	} else {
+		// Run each method concurrently
+		for i, methodName := range runallMethods {
+			wg.Add(1)
+			go func(method string, methodIndex int) {
+				defer wg.Done()
 
-			result := runSingleMethod(method, accounts, methodIndex+1, len(methods), progressManager)
+				result := runSingleMethod(targetURL, method, suiteTargets(method, accounts), methodIndex+1, len(runallMethods), progressManager)
 
-			mutex.Lock()
-			results = append(results, result)
-			mutex.Unlock()
-		}(methodName, i)
-	}
+				mutex.Lock()
+				results = append(results, result)
+				mutex.Unlock()
+			}(methodName, i)
+		}
 
-	wg.Wait()
+		wg.Wait()
+	}
 
 	// Stop progress display
 	progressManager.Stop()
 
-	// Wait for the display goroutine to finish
-	time.Sleep(500 * time.Millisecond)
+	// Wait for the display goroutine to finish so it can't draw over the results
+	progressManager.Wait()
 
 	// Simple completion message without complex clearing
-	fmt.Println()
-	fmt.Println("    ✅ All methods completed successfully!")
-	fmt.Println()
+	fmt.Fprintln(output)
+	fmt.Fprintln(output, "    ✅ All methods completed successfully!")
+	fmt.Fprintln(output)
 
-	return results, nil
+	return results
 }
 
-// runSingleMethod runs a single method test and returns the result
-func runSingleMethod(methodName string, accounts []string, methodIndex, totalMethods int, progressManager *ProgressManager) TestResult {
-	fmt.Printf("  🔄 [%d/%d] Starting %s test...\n", methodIndex, totalMethods, methodName)
+// suitePrograms are the programs runall's program methods enumerate, the config's or --program's. Unset, as in
+// benchmark, program methods take their programs from the accounts like the single method commands do
+var suitePrograms []string
 
-	// Create RPC client with target RPC URL (from --url flag)
-	rpcTest := methods.NewRPCTest(rpcURL, apiKey)
+// suiteTargets returns what methodName requests in the suite: the suite's programs for program methods, the
+// method's window of the accounts otherwise
+func suiteTargets(methodName string, accounts []string) []string {
+	if programMethods[methodName] && suitePrograms != nil {
+		return suitePrograms
+	}
+	return methodAccounts(methodName, accounts)
+}
 
-	startTime := time.Now()
-	endTime := startTime.Add(time.Duration(duration) * time.Second)
+// runSingleMethod runs one method of the suite against targetURL, reporting to the shared progress display
+func runSingleMethod(targetURL string, methodName string, accounts []string, methodIndex, totalMethods int, progressManager *ProgressManager) TestResult {
+	fmt.Fprintf(output, "  🔄 [%d/%d] Starting %s test...\n", methodIndex, totalMethods, methodName)
 
-	var wg sync.WaitGroup
-	var successCount, failureCount int64
-	var mutex sync.Mutex
+	// The load fails the method when filtering left nothing to request
+	if len(accounts) == 0 && !parameterlessMethods[methodName] {
+		fmt.Fprintf(output, "  ❌ %s: %s\n", methodName, noTargetsError(methodName))
+	}
 
-	// Collect statistics
-	var totalLatency time.Duration
-	var minLatency time.Duration = time.Hour
-	var maxLatency time.Duration
-
-	// Create channels for workers
-	stop := make(chan struct{})
-
-	// Progress update ticker
-	progressTicker := time.NewTicker(500 * time.Millisecond)
-	defer progressTicker.Stop()
-
-	// Progress update goroutine
-	go func() {
-		for {
-			select {
-			case <-progressTicker.C:
-				if time.Now().After(endTime) {
-					return
-				}
-				mutex.Lock()
-				progressManager.UpdateProgress(methodName, successCount, failureCount)
-				mutex.Unlock()
-			case <-stop:
-				return
-			}
+	// Create RPC client with target RPC URL (from --url flag), program methods enumerate what seeding did
+	rpcTest := methods.NewRPCTestWithOptions(targetURL, apiKey, clientOptions())
+	rpcTest.SetCallOptions(callOptions())
+	if programMethods[methodName] {
+		applyProgramFilters(rpcTest)
+	}
+	return runMethodLoadOn(methodName, rpcTest, loadTarget{url: targetURL, accounts: accounts, progress: progressManager})
+}
+
+// coolDown pauses for --cooldown between sequential methods, idle or pinging targetURL with getSlot
+// once a second under --cooldown-ping
+func coolDown(targetURL string) {
+	if cooldown <= 0 {
+		return
+	}
+
+	end := time.Now().Add(time.Duration(cooldown) * time.Second)
+	if !cooldownPing {
+		fmt.Fprintf(output, "  😴 Cooling down for %ds...\n", cooldown)
+		time.Sleep(time.Until(end))
+		return
+	}
+
+	fmt.Fprintf(output, "  😴 Cooling down for %ds with getSlot pings...\n", cooldown)
+	rpcTest := methods.NewRPCTestWithOptions(targetURL, apiKey, clientOptions())
+	var failed int
+	for time.Now().Before(end) {
+		if _, err := rpcTest.LatestSlot(context.Background()); err != nil {
+			failed++
 		}
-	}()
-
-	// Start workers
-	for i := 0; i < concurrency; i++ {
-		wg.Add(1)
-		go func(workerID int) {
-			defer wg.Done()
-
-			for {
-				select {
-				case <-stop:
-					return
-				default:
-					// Check if test duration has elapsed
-					if time.Now().After(endTime) {
-						return
-					}
-
-					// Execute the specified method
-					startReq := time.Now()
-					var err error
-
-					if methodName == "getMultipleAccounts" {
-						// For getMultipleAccounts, use multiple accounts
-						// Take up to 5 accounts for each request
-						numAccounts := rand.Intn(10) + 5
-						if len(accounts) < numAccounts {
-							numAccounts = len(accounts)
-						}
-
-						// Create a batch of accounts starting from workerID
-						var batchAccounts []string
-						for i := 0; i < numAccounts; i++ {
-							accountIndex := (workerID + i) % len(accounts)
-							batchAccounts = append(batchAccounts, accounts[accountIndex])
-						}
-
-						err = Method(methodName, rpcTest, batchAccounts...)
-					} else {
-						// For other methods, use single account
-						err = Method(methodName, rpcTest, accounts[workerID%len(accounts)])
-					}
-
-					reqDuration := time.Since(startReq)
-
-					mutex.Lock()
-					if err != nil {
-						fmt.Printf("  ❌ Error: %v\n", err)
-						failureCount++
-					} else {
-						successCount++
-						totalLatency += reqDuration
-						if reqDuration < minLatency {
-							minLatency = reqDuration
-						}
-						if reqDuration > maxLatency {
-							maxLatency = reqDuration
-						}
-					}
-					mutex.Unlock()
-				}
-			}
-		}(i)
+		time.Sleep(min(time.Second, time.Until(end)))
 	}
+	if failed > 0 {
+		fmt.Fprintf(output, "  ⚠️  %d cooldown pings failed\n", failed)
+	}
+}
 
-	// Wait for the test duration
-	time.Sleep(time.Duration(duration) * time.Second)
-	close(stop)
+// suiteMode names how the suite's methods run, recorded in the run metadata
+func suiteMode() string {
+	if sequentialSuite {
+		return suiteModeSequential
+	}
+	return suiteModeConcurrent
+}
+
+// enabledSuite returns the suite without the --disable methods, failing on a method the suite doesn't run
+// or when none would be left
+func enabledSuite(suite []string, disabled []string) ([]string, error) {
+	off := make(map[string]bool)
+	for _, method := range disabled {
+		method = strings.TrimSpace(method)
+		if !slices.Contains(suite, method) {
+			return nil, fmt.Errorf("invalid --disable: %s is not one of the runall methods (%s)", method, strings.Join(suite, ", "))
+		}
+		off[method] = true
+	}
+
+	var enabled []string
+	for _, method := range suite {
+		if !off[method] {
+			enabled = append(enabled, method)
+		}
+	}
+	if len(enabled) == 0 {
+		return nil, fmt.Errorf("invalid --disable: every runall method is disabled, leave at least one")
+	}
+	return enabled, nil
+}
 
-	// Wait for all workers to finish
-	wg.Wait()
+// orderedSuite returns the suite with the --order methods first, in that order, and the rest after them
+// in their usual order, failing on a method the suite doesn't run or one listed twice
+func orderedSuite(suite []string, order []string) ([]string, error) {
+	inSuite := make(map[string]bool)
+	for _, method := range suite {
+		inSuite[method] = true
+	}
 
-	// Final progress update
-	progressManager.UpdateProgress(methodName, successCount, failureCount)
+	listed := make(map[string]bool)
+	var ordered []string
+	for _, method := range order {
+		method = strings.TrimSpace(method)
+		if !inSuite[method] {
+			return nil, fmt.Errorf("invalid --order: %s is not one of the runall methods (%s)", method, strings.Join(suite, ", "))
+		}
+		if listed[method] {
+			return nil, fmt.Errorf("invalid --order: %s is listed twice", method)
+		}
+		listed[method] = true
+		ordered = append(ordered, method)
+	}
+	for _, method := range suite {
+		if !listed[method] {
+			ordered = append(ordered, method)
+		}
+	}
+	return ordered, nil
+}
 
-	// Calculate results
-	totalDuration := time.Since(startTime)
-	totalRequests := successCount + failureCount
-	requestsPerSecond := float64(totalRequests) / totalDuration.Seconds()
-	successRate := float64(successCount) / float64(totalRequests) * 100
+// suiteModeLabel describes the suite mode and what its numbers measure
+func suiteModeLabel() string {
+	if len(methodOrder) > 0 {
+		return fmt.Sprintf("sequential in --order: %s", strings.Join(runallMethods, " → "))
+	}
+	if sequentialSuite {
+		return "sequential (each method runs alone, isolated per-method numbers)"
+	}
+	return "concurrent (all methods at once, numbers include contention between methods)"
+}
 
-	var avgLatency time.Duration
-	if successCount > 0 {
-		avgLatency = totalLatency / time.Duration(successCount)
+// noTargetsError describes a method left without anything to request: programs for program methods,
+// accounts for the others
+func noTargetsError(methodName string) string {
+	if programMethods[methodName] {
+		return "no programs available"
 	}
+	return "no accounts available"
+}
 
-	return TestResult{
-		MethodName:     methodName,
-		Duration:       totalDuration,
-		TotalRequests:  totalRequests,
-		SuccessCount:   successCount,
-		FailureCount:   failureCount,
-		RequestsPerSec: requestsPerSecond,
-		SuccessRate:    successRate,
-		MinLatency:     minLatency,
-		MaxLatency:     maxLatency,
-		AvgLatency:     avgLatency,
+// noAccountsResult is the result of a method that had no accounts, or programs, to request
+func noAccountsResult(methodName string) TestResult {
+	result := TestResult{
+		MethodName: methodName,
+		Error:      noTargetsError(methodName),
 	}
+	logMethodFinished(result)
+	return result
 }
 
 // calculateOverallResults calculates overall statistics
@@ -653,56 +1086,170 @@ func calculateOverallResults(methodResults []TestResult) OverallResult {
 	}
 }
 
-// formatLatency formats latency in the most appropriate unit
+// formatLatency formats latency in the unit forced by --latency-unit, or the most appropriate one
 func formatLatency(duration time.Duration) string {
+	if latencyUnit != latencyUnitAuto {
+		return formatForcedLatency(duration)
+	}
 	if duration < time.Millisecond {
-		return fmt.Sprintf("%.2f μs", float64(duration.Microseconds()))
+		return fmt.Sprintf("%.*f μs", precision, float64(duration.Microseconds()))
 	} else if duration < time.Second {
-		return fmt.Sprintf("%.2f ms", float64(duration.Milliseconds()))
+		return fmt.Sprintf("%.*f ms", precision, float64(duration.Milliseconds()))
 	} else {
-		return fmt.Sprintf("%.2f s", duration.Seconds())
+		return fmt.Sprintf("%.*f s", precision, duration.Seconds())
 	}
 }
 
+// formatBytes formats a byte count in the most appropriate unit
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.2f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// formatErrorBreakdown lists failures per error kind, connect timeouts first
+func formatErrorBreakdown(errorKinds map[string]int64) string {
+	var parts []string
+	for _, kind := range []string{methods.ErrorKindConnectTimeout, methods.ErrorKindRequestTimeout, methods.ErrorKindRateLimited, methods.ErrorKindOther, methods.ErrorKindRPC, methods.ErrorKindDecode, methods.ErrorKindConformance, methods.ErrorKindSlotNotReached, methods.ErrorKindNullAccounts} {
+		if count := errorKinds[kind]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%s=%d", kind, count))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// separateErrorKinds are responses that arrived but were judged unusable: neither a transport failure nor an
+// error the RPC returned
+var separateErrorKinds = map[string]bool{
+	methods.ErrorKindDecode:         true,
+	methods.ErrorKindConformance:    true,
+	methods.ErrorKindSlotNotReached: true,
+	methods.ErrorKindNullAccounts:   true,
+}
+
+// splitFailures separates endpoint/network failures from errors the RPC returned for the request,
+// the separateErrorKinds are neither and are reported on their own
+func splitFailures(errorKinds map[string]int64) (transportFailures, rpcFailures int64) {
+	for kind, count := range errorKinds {
+		if separateErrorKinds[kind] {
+			continue
+		}
+		if methods.IsTransportErrorKind(kind) {
+			transportFailures += count
+		} else {
+			rpcFailures += count
+		}
+	}
+	return transportFailures, rpcFailures
+}
+
 // displayResults displays comprehensive test results
 func displayResults(methodResults []TestResult, overall OverallResult) {
-	fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println("📊 COMPREHENSIVE TEST RESULTS")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "📊 COMPREHENSIVE TEST RESULTS")
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	printRunMetadata(overall.Metadata)
 
 	// Display individual method results
-	fmt.Println("\n🔍 INDIVIDUAL METHOD RESULTS:")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "\n🔍 INDIVIDUAL METHOD RESULTS:")
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	for _, result := range methodResults {
-		fmt.Printf("\n📈 %s:\n", strings.ToUpper(result.MethodName))
-		fmt.Printf("   Duration:         %.2f seconds\n", result.Duration.Seconds())
-		fmt.Printf("   Total Requests:    %d\n", result.TotalRequests)
-		fmt.Printf("   Successful:        %d (%.2f%%)\n", result.SuccessCount, result.SuccessRate)
-		fmt.Printf("   Failed:            %d (%.2f%%)\n", result.FailureCount, 100-result.SuccessRate)
-		fmt.Printf("   Requests/second:   %.2f\n", result.RequestsPerSec)
+		fmt.Fprintf(output, "\n📈 %s:\n", strings.ToUpper(result.MethodName))
+		if result.Error != "" {
+			fmt.Fprintf(output, "   ❌ Not run:        %s\n", result.Error)
+			continue
+		}
+		fmt.Fprintf(output, "   Duration:         %s seconds\n", formatDecimal(result.Duration.Seconds()))
+		fmt.Fprintf(output, "   Total Requests:    %s\n", formatCount(result.TotalRequests))
+		fmt.Fprintf(output, "   Successful:        %s (%s%%)\n", formatCount(result.SuccessCount), formatDecimal(result.SuccessRate))
+		if result.EmptyCount > 0 {
+			fmt.Fprintf(output, "     Empty (null):    %s\n", formatCount(result.EmptyCount))
+		}
+		if result.PartialResponseCount > 0 {
+			fmt.Fprintf(output, "     Partial:         %s\n", formatCount(result.PartialResponseCount))
+		}
+		fmt.Fprintf(output, "   Failed:            %s (%s%%)\n", formatCount(result.FailureCount), formatDecimal(100-result.SuccessRate))
+		if result.FailureCount > 0 {
+			transportFailures, rpcFailures := splitFailures(result.ErrorKinds)
+			fmt.Fprintf(output, "     Transport:       %s\n", formatCount(transportFailures))
+			fmt.Fprintf(output, "     RPC:             %s\n", formatCount(rpcFailures))
+			if malformed := result.ErrorKinds[methods.ErrorKindDecode]; malformed > 0 {
+				fmt.Fprintf(output, "     Malformed:       %s\n", formatCount(malformed))
+			}
+			if behind := result.ErrorKinds[methods.ErrorKindSlotNotReached]; behind > 0 {
+				fmt.Fprintf(output, "     Slot not reached: %s\n", formatCount(behind))
+			}
+			if nulls := result.ErrorKinds[methods.ErrorKindNullAccounts]; nulls > 0 {
+				fmt.Fprintf(output, "     Too many nulls:  %s\n", formatCount(nulls))
+			}
+			if nonconforming := result.ErrorKinds[methods.ErrorKindConformance]; nonconforming > 0 {
+				fmt.Fprintf(output, "     Nonconforming:   %s\n", formatCount(nonconforming))
+			}
+		}
+		if breakdown := formatErrorBreakdown(result.ErrorKinds); breakdown != "" {
+			fmt.Fprintf(output, "   Errors:            %s\n", breakdown)
+		}
+		printErrorCodes(result.ErrorCodes, "   ")
+		if result.BreakerTrips > 0 {
+			fmt.Fprintf(output, "   Breaker:           opened %d times, %s requests skipped\n", result.BreakerTrips, formatCount(result.SkippedByBreaker))
+		}
+		if result.Retries > 0 {
+			fmt.Fprintf(output, "   Retries:           %s\n", formatCount(result.Retries))
+		}
+		fmt.Fprintf(output, "   Requests/second:   %s\n", formatDecimal(result.RequestsPerSec))
+		if result.SettledRate > 0 {
+			fmt.Fprintf(output, "   Adaptive rate:     settled at %s RPS\n", formatDecimal(result.SettledRate))
+		}
+		fmt.Fprintf(output, "   Transferred:       %s (%s decoded)\n", formatBytes(result.WireBytes), formatBytes(result.DecodedBytes))
+		if summary := connectionSummary(result); summary != "" {
+			fmt.Fprintf(output, "   Connections:       %s\n", summary)
+		}
+		if result.AccountPicks > 0 {
+			fmt.Fprintf(output, "   Hot Set Hits:      %s%%\n", formatDecimal(float64(result.HotSetHits)/float64(result.AccountPicks)*100))
+		}
 		if result.SuccessCount > 0 {
-			fmt.Printf("   Min Latency:       %s\n", formatLatency(result.MinLatency))
-			fmt.Printf("   Max Latency:       %s\n", formatLatency(result.MaxLatency))
-			fmt.Printf("   Avg Latency:       %s\n", formatLatency(result.AvgLatency))
+			fmt.Fprintf(output, "   Min Latency:       %s\n", formatLatency(result.MinLatency))
+			fmt.Fprintf(output, "   Max Latency:       %s\n", formatLatency(result.MaxLatency))
+			fmt.Fprintf(output, "   Avg Latency:       %s\n", formatLatency(result.AvgLatency))
+			fmt.Fprintf(output, "   P95 Latency:       %s\n", formatLatency(result.P95Latency))
+			if label := slaComplianceLabel(result); label != "" {
+				fmt.Fprintf(output, "   SLA Compliance:    %s\n", label)
+			}
+		}
+		if summary := slotLagSummary(result); summary != "" {
+			fmt.Fprintf(output, "   Slot Lag:          %s\n", summary)
+		}
+		if warning := cacheHitWarning(result); warning != "" {
+			fmt.Fprintf(output, "   ⚠️  Cache hit:      %s\n", warning)
+		}
+		if warning := slotLagWarning(result); warning != "" {
+			fmt.Fprintf(output, "   ⚠️  Stale:          %s\n", warning)
 		}
 	}
 
 	// Display overall results
-	fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println("🎯 OVERALL TEST SUMMARY")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Printf("🕒 Total Duration:     %.2f seconds\n", overall.TotalDuration.Seconds())
-	fmt.Printf("🔢 Total Requests:      %d\n", overall.TotalRequests)
-	fmt.Printf("✅ Total Successful:    %d (%.2f%%)\n", overall.TotalSuccess, overall.OverallSuccessRate)
-	fmt.Printf("❌ Total Failed:        %d (%.2f%%)\n", overall.TotalFailure, 100-overall.OverallSuccessRate)
-	fmt.Printf("⚡ Overall RPS:         %.2f\n", overall.OverallRPS)
-	fmt.Printf("📊 Methods Tested:      %d\n", len(methodResults))
+	fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "🎯 OVERALL TEST SUMMARY")
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(output, "🕒 Total Duration:     %s seconds\n", formatDecimal(overall.TotalDuration.Seconds()))
+	fmt.Fprintf(output, "🔢 Total Requests:      %s\n", formatCount(overall.TotalRequests))
+	fmt.Fprintf(output, "✅ Total Successful:    %s (%s%%)\n", formatCount(overall.TotalSuccess), formatDecimal(overall.OverallSuccessRate))
+	fmt.Fprintf(output, "❌ Total Failed:        %s (%s%%)\n", formatCount(overall.TotalFailure), formatDecimal(100-overall.OverallSuccessRate))
+	fmt.Fprintf(output, "⚡ Overall RPS:         %s\n", formatDecimal(overall.OverallRPS))
+	fmt.Fprintf(output, "📊 Methods Tested:      %d\n", len(methodResults))
 
 	// Performance insights
-	fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println("💡 PERFORMANCE INSIGHTS")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "💡 PERFORMANCE INSIGHTS")
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	// Find best and worst performing methods
 	var bestMethod, worstMethod TestResult
@@ -719,17 +1266,17 @@ func displayResults(methodResults []TestResult, overall OverallResult) {
 		}
 	}
 
-	fmt.Printf("🏆 Best Performing:    %s (%.2f RPS)\n", bestMethod.MethodName, bestRPS)
-	fmt.Printf("🐌 Worst Performing:   %s (%.2f RPS)\n", worstMethod.MethodName, worstRPS)
+	fmt.Fprintf(output, "🏆 Best Performing:    %s (%s RPS)\n", bestMethod.MethodName, formatDecimal(bestRPS))
+	fmt.Fprintf(output, "🐌 Worst Performing:   %s (%s RPS)\n", worstMethod.MethodName, formatDecimal(worstRPS))
 
 	if bestRPS > 0 {
 		performanceRatio := worstRPS / bestRPS * 100
-		fmt.Printf("📊 Performance Ratio:  %.1f%% (worst/best)\n", performanceRatio)
+		fmt.Fprintf(output, "📊 Performance Ratio:  %s%% (worst/best)\n", formatDecimal(performanceRatio))
 	}
 
 	// Add latency comparison
 	if len(methodResults) > 0 {
-		fmt.Println("\n⏱️  LATENCY COMPARISON:")
+		fmt.Fprintln(output, "\n⏱️  LATENCY COMPARISON:")
 		var fastestMethod, slowestMethod TestResult
 		var fastestLatency, slowestLatency time.Duration
 
@@ -747,17 +1294,17 @@ func displayResults(methodResults []TestResult, overall OverallResult) {
 		}
 
 		if fastestLatency > 0 {
-			fmt.Printf("⚡ Fastest Method:     %s (%s avg)\n", fastestMethod.MethodName, formatLatency(fastestLatency))
-			fmt.Printf("🐌 Slowest Method:     %s (%s avg)\n", slowestMethod.MethodName, formatLatency(slowestLatency))
+			fmt.Fprintf(output, "⚡ Fastest Method:     %s (%s avg)\n", fastestMethod.MethodName, formatLatency(fastestLatency))
+			fmt.Fprintf(output, "🐌 Slowest Method:     %s (%s avg)\n", slowestMethod.MethodName, formatLatency(slowestLatency))
 
 			if fastestLatency > 0 {
 				latencyRatio := float64(slowestLatency) / float64(fastestLatency)
-				fmt.Printf("📊 Latency Ratio:      %.1fx (slowest/fastest)\n", latencyRatio)
+				fmt.Fprintf(output, "📊 Latency Ratio:      %sx (slowest/fastest)\n", formatDecimal(latencyRatio))
 			}
 		}
 	}
 
-	fmt.Println("\n✅ Comprehensive test suite completed successfully!")
+	fmt.Fprintln(output, "\n✅ Comprehensive test suite completed successfully!")
 }
 
 func init() {
@@ -767,5 +1314,25 @@ func init() {
 	runallCmd.Flags().IntVarP(&concurrency, "concurrency", "c", 5, "Number of concurrent requests per method")
 	runallCmd.Flags().IntVarP(&duration, "duration", "d", 15, "Test duration in seconds per method")
 	runallCmd.Flags().IntVarP(&limit, "limit", "l", 0, "Limit the number of accounts to use (0 for no limit)")
+	runallCmd.Flags().StringArrayVar(&methodOffsets, "method-offset", []string{}, "METHOD=OFFSET giving the method its own window of --limit accounts starting at OFFSET, e.g. to keep methods off each other's cached accounts (can be specified multiple times)")
 	runallCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API key for RPC endpoint (will be saved in config)")
+	runallCmd.Flags().BoolVar(&regenConfig, "regen-config", false, "Regenerate config.json even when it exists, keeping its API key unless --api-key is given")
+	runallCmd.Flags().StringArrayVarP(&runallPrograms, "program", "p", []string{}, "Program to seed accounts from instead of the config's programs, into a fresh test_accounts_program.txt (can be specified multiple times)")
+	runallCmd.Flags().StringArrayVar(&programDiscriminators, "programs-discriminator", []string{}, "PROGRAM=VALUE seeding only the program's accounts starting with this discriminator, overriding the config's program_info (can be specified multiple times)")
+	runallCmd.Flags().IntVar(&discriminatorSize, "discriminator-size", defaultDiscriminatorSize, "Bytes of the --programs-discriminator values: 1, 2, 4 or 8 (anchor)")
+	runallCmd.Flags().IntVar(&runallSeedLimit, "seed-limit", 100, "Number of accounts to seed per program (use well above --concurrency to avoid workers colliding on the same accounts)")
+	runallCmd.Flags().BoolVar(&reuseAccounts, "reuse-accounts", false, "Skip seeding when test_accounts.txt in --data-dir is non-empty and younger than --accounts-ttl (ignored with --program)")
+	runallCmd.Flags().DurationVar(&accountsTTL, "accounts-ttl", time.Hour, "Maximum age of the accounts file reused by --reuse-accounts")
+	runallCmd.Flags().BoolVar(&summaryOnly, "summary-only", false, "Print only one overall summary line (overall_rps=... success_rate=...) instead of the full report, for scripts")
+	runallCmd.Flags().BoolVar(&sequentialSuite, "sequential", false, "Run the methods one after another for isolated per-method numbers instead of all at once")
+	runallCmd.Flags().BoolVar(&probeAccounts, "probe-accounts", false, "Check the accounts with getMultipleAccounts before the run and drop the ones the target serves as null")
+	runallCmd.Flags().BoolVar(&probeWrite, "probe-write", false, "Write the accounts left by --probe-accounts back to the accounts file")
+	runallCmd.Flags().IntVar(&cooldown, "cooldown", 0, "Seconds to pause between sequential methods so the endpoint settles (needs --sequential or --order)")
+	runallCmd.Flags().BoolVar(&cooldownPing, "cooldown-ping", false, "Send a getSlot request every second during --cooldown instead of leaving the endpoint idle")
+	runallCmd.Flags().StringSliceVar(&disabledMethods, "disable", []string{}, "Leave this method out of the run, e.g. getProgramAccounts for a quick run (can specify multiple)")
+	runallCmd.Flags().StringSliceVar(&methodOrder, "order", []string{}, "Comma separated methods to run first, one after another in this order, e.g. to warm caches with getProgramAccounts (implies --sequential)")
+	runallCmd.Flags().BoolVar(&noSeed, "no-seed", false, "Skip seeding and test the accounts in --account-file as is (no remote RPC needed)")
+	runallCmd.Flags().StringVar(&cpuProfile, "cpuprofile", "", "Write a CPU profile of the load generator to this file")
+	runallCmd.Flags().StringVar(&memProfile, "memprofile", "", "Write a heap profile of the load generator to this file on exit")
+	runallCmd.Flags().StringVar(&pprofAddr, "pprof-addr", "", "Serve live net/http/pprof on this address (e.g. localhost:6060)")
 }
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"rpc_test/methods"
+)
+
+func TestProgressManagerStopTwice(t *testing.T) {
+	pm := NewProgressManager()
+	pm.RegisterMethod("getAccountInfo", 1)
+	go pm.StartProgressDisplay()
+
+	// The interrupt and the completion path can both stop the display, at the same time
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pm.Stop()
+		}()
+	}
+	wg.Wait()
+	pm.Stop()
+
+	waited := make(chan struct{})
+	go func() {
+		pm.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("display loop did not return after Stop")
+	}
+}
+
+func TestDisplayProgressRedrawsEveryMethod(t *testing.T) {
+	saved := runallMethods
+	t.Cleanup(func() { runallMethods = saved })
+	runallMethods = []string{"getAccountInfo", "getMultipleAccounts", "getProgramAccounts", "getSupply", "getVoteAccounts"}
+
+	pm := NewProgressManager()
+	pm.RegisterMethod("getAccountInfo", 1)
+
+	var drawn bytes.Buffer
+	t.Cleanup(func() { output = os.Stdout })
+	output = &drawn
+	pm.DisplayProgress()
+	pm.DisplayProgress()
+
+	// The second draw moves up over all five lines of the first
+	first, second, ok := strings.Cut(drawn.String(), "\033[5A")
+	if !ok || strings.Contains(second, "\033[5A") {
+		t.Fatalf("want one move up by 5 lines between the draws, got %q", drawn.String())
+	}
+	if lines := strings.Count(first, "\n"); lines != len(runallMethods) {
+		t.Fatalf("first draw has %d lines, want %d", lines, len(runallMethods))
+	}
+}
+
+func TestSummaryOnlyKeepsStdout(t *testing.T) {
+	stdout := os.Stdout
+	t.Cleanup(func() { setOutput(stdout) })
+	var console bytes.Buffer
+	setOutput(&console)
+
+	// --summary-only discards the decorated output but leaves the process's stdout alone
+	summaryOut := silenceOutput()
+	fmt.Fprintln(output, "🚀 decorated")
+	printSummaryLine(summaryOut, OverallResult{TotalRequests: 3, TotalSuccess: 3})
+
+	if os.Stdout != stdout {
+		t.Fatal("os.Stdout was replaced")
+	}
+	if got := console.String(); strings.Contains(got, "decorated") || !strings.HasPrefix(got, "overall_rps=") || !strings.Contains(got, "total_requests=3") {
+		t.Fatalf("console got %q, want only the summary line", got)
+	}
+}
+
+func TestSeedAccountsFromProgramEmptyWithStaleFile(t *testing.T) {
+	// The program has no accounts left
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":[]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	t.Cleanup(func() { output = os.Stdout; methods.SetOutput(os.Stdout) })
+	output = io.Discard
+	methods.SetOutput(io.Discard)
+
+	// An earlier run left accounts in the file, which must not pass for this seed's
+	accountsFile := filepath.Join(t.TempDir(), "test_accounts.txt")
+	if err := os.WriteFile(accountsFile, []byte("SysvarRent111111111111111111111111111111111\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := TestConfig{RemoteRPCURL: server.URL, Programs: []string{"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"}}
+	err := seedAccountsFromProgram(accountsFile, config, 0)
+	if err == nil || !strings.Contains(err.Error(), "seeding produced no accounts") {
+		t.Fatalf("got error %v, want seeding produced no accounts", err)
+	}
+}
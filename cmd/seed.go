@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"rpc_test/methods"
 
@@ -14,8 +15,24 @@ import (
 
 var (
 	outputFile string
+	owners     []string
+	seedTokens bool
+	sampleMode string
+	sampleSeed int64
+	failFast   bool
+
+	// seedBlocks seeds the accounts touched by this many recent blocks instead of program accounts
+	seedBlocks int
 )
 
+// seedOutcome records what one program or owner contributed to the output file
+type seedOutcome struct {
+	Source   string
+	Added    int
+	Duration time.Duration
+	Err      error
+}
+
 // seedCmd represents the seed command
 var seedCmd = &cobra.Command{
 	Use:   "seed",
@@ -32,6 +49,10 @@ Features:
 • Limit Support: Control the number of accounts to fetch with --limit flag
 • Directory Creation: Automatically creates output directories if they don't exist
 • Multiple Programs: Support for fetching from multiple programs simultaneously
+• Token Accounts: Seed a wallet's SPL token accounts with --token --owner
+• Hot Accounts: Seed the accounts touched by recent blocks with --accounts-from-block
+• Deduplication: Addresses already in the output file are not written again
+• Seeding Summary: Lists which programs succeeded or failed and how many accounts each added
 
 Use Cases:
 • Prepare account lists for performance testing
@@ -50,7 +71,19 @@ Examples:
   rpc_test seed --program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA --program 2wT8Yq49kHgDzXuPxZSaeLaH1qbmGXtEyPy64bL7aD3c --output ./data/accounts.txt
 
   # Seed from programs listed in a file
-  rpc_test seed --program-file ./programs.txt --output ./data/test_accounts.txt --limit 500`,
+  rpc_test seed --program-file ./programs.txt --output ./data/test_accounts.txt --limit 500
+
+  # Seed a reproducible random sample instead of the first N accounts
+  rpc_test seed --program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA --limit 1000 --sample random --sample-seed 42
+
+  # Seed only one account type of an anchor program
+  rpc_test seed --program 2wT8Yq49kHgDzXuPxZSaeLaH1qbmGXtEyPy64bL7aD3c --programs-discriminator 2wT8Yq49kHgDzXuPxZSaeLaH1qbmGXtEyPy64bL7aD3c=0x8f5c9a2e71d4b306 --limit 1000
+
+  # Seed the token accounts of a wallet
+  rpc_test seed --token --owner WALLET_ADDRESS --output ./data/token_accounts.txt
+
+  # Seed the 1000 most touched accounts of the last 5 blocks
+  rpc_test seed --accounts-from-block 5 --limit 1000 --output ./data/hot_accounts.txt`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Load programs from file if provided
 		if programsFile != "" {
@@ -68,9 +101,32 @@ Examples:
 			}
 		}
 
-		if len(programs) == 0 {
+		if err := methods.ValidateSampleMode(sampleMode); err != nil {
+			log.Fatalf("Invalid --sample: %v", err)
+		}
+		if sampleMode == methods.SampleRandom && !cmd.Flags().Changed("sample-seed") {
+			sampleSeed = time.Now().UnixNano()
+		}
+
+		if seedBlocks < 0 {
+			log.Fatalf("--accounts-from-block must be positive")
+		}
+		if seedBlocks > 0 {
+			if seedTokens || len(programs) > 0 {
+				log.Fatalf("--accounts-from-block cannot be combined with --token or --program")
+			}
+		} else if seedTokens {
+			if len(owners) == 0 {
+				log.Fatalf("No owners provided. Use --owner to specify the wallets whose token accounts to seed")
+			}
+		} else if len(owners) > 0 {
+			log.Fatalf("--owner requires --token")
+		} else if len(programs) == 0 {
 			log.Fatalf("No programs provided. Use --program or --program-file to specify programs")
 		}
+		if err := resolveProgramFilters(nil); err != nil {
+			log.Fatal(err)
+		}
 
 		// Create output directory if needed
 		outputDir := filepath.Dir(outputFile)
@@ -80,25 +136,112 @@ Examples:
 			}
 		}
 
-		fmt.Printf("Fetching accounts for %d programs\n", len(programs))
+		if seedBlocks > 0 {
+			fmt.Fprintf(output, "Fetching accounts touched by the last %d blocks\n", seedBlocks)
+			seedSources("block set", []string{fmt.Sprintf("last %d blocks", seedBlocks)}, seedBlockAccounts)
+			return
+		}
 
-		for _, program := range programs {
-			fmt.Printf("Processing program: %s\n", program)
-			err := seedProgramAccounts(program, outputFile)
-			if err != nil {
-				log.Printf("Error processing program %s: %v", program, err)
-			}
+		if seedTokens {
+			fmt.Fprintf(output, "Fetching token accounts for %d owners\n", len(owners))
+			seedSources("owner", owners, seedTokenAccounts)
+			return
 		}
+
+		fmt.Fprintf(output, "Fetching accounts for %d programs\n", len(programs))
+		seedSources("program", programs, seedProgramAccounts)
 	},
 }
 
+// seedSources seeds every program or owner in turn, aborting on the first error with --fail-fast, then prints a summary
+func seedSources(kind string, sources []string, seed func(source string, outputFile string) error) {
+	var outcomes []seedOutcome
+
+	for _, source := range sources {
+		fmt.Fprintf(output, "Processing %s: %s\n", kind, source)
+		logEvent("seeding_started", kind, source, "output", outputFile)
+
+		before := countSeededAccounts(outputFile)
+		start := time.Now()
+		err := seed(source, outputFile)
+		outcome := seedOutcome{Source: source, Added: countSeededAccounts(outputFile) - before, Duration: time.Since(start), Err: err}
+		outcomes = append(outcomes, outcome)
+
+		if err != nil {
+			if failFast {
+				log.Fatalf("Error processing %s %s: %v (aborting because of --fail-fast)", kind, source, err)
+			}
+			log.Printf("Error processing %s %s: %v", kind, source, err)
+			continue
+		}
+		logEvent("seeding_finished", kind, source, "output", outputFile, "accounts", outcome.Added, "duration_s", outcome.Duration.Seconds())
+	}
+
+	printSeedSummary(kind, outcomes)
+}
+
+// printSeedSummary lists which sources succeeded or failed and how many accounts each contributed
+func printSeedSummary(kind string, outcomes []seedOutcome) {
+	var succeeded, failed, added int
+	var total time.Duration
+
+	fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "🌱 SEEDING SUMMARY")
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, outcome := range outcomes {
+		added += outcome.Added
+		total += outcome.Duration
+		if outcome.Err != nil {
+			failed++
+			fmt.Fprintf(output, "❌ %s: %v\n", outcome.Source, outcome.Err)
+		} else {
+			succeeded++
+			fmt.Fprintf(output, "✅ %s: %d accounts in %s\n", outcome.Source, outcome.Added, outcome.Duration.Round(time.Millisecond))
+		}
+	}
+	fmt.Fprintf(output, "\n%d %ss succeeded, %d failed, %d accounts added to %s\n", succeeded, kind, failed, added, outputFile)
+	fmt.Fprintf(output, "⏱️  Total seeding time: %s\n", total.Round(time.Millisecond))
+}
+
+// countSeededAccounts returns the number of addresses in the output file, 0 when it doesn't exist yet
+func countSeededAccounts(outputFile string) int {
+	lines, err := methods.ReadAccountFile(outputFile)
+	if err != nil {
+		return 0
+	}
+	return len(lines)
+}
+
 // seedProgramAccounts fetches and saves program accounts
 func seedProgramAccounts(programAddress string, outputFile string) error {
 	// Create RPC client
 	rpcTest := methods.NewRPCTest(rpcURL, apiKey)
+	applyProgramFilters(rpcTest)
 
 	// Seed program accounts
-	return rpcTest.SeedProgramAccounts(programAddress, outputFile, limit)
+	_, err := rpcTest.SeedProgramAccountsSampled(programAddress, outputFile, limit, methods.SampleOptions{
+		Mode: sampleMode,
+		Seed: sampleSeed,
+	})
+	return err
+}
+
+// seedTokenAccounts fetches and saves the token accounts of a wallet
+func seedTokenAccounts(owner string, outputFile string) error {
+	// Create RPC client
+	rpcTest := methods.NewRPCTest(rpcURL, apiKey)
+
+	// Seed token accounts
+	return rpcTest.SeedTokenAccounts(owner, outputFile, limit)
+}
+
+// seedBlockAccounts fetches and saves the accounts touched by the last --accounts-from-block blocks
+func seedBlockAccounts(_ string, outputFile string) error {
+	// Create RPC client
+	rpcTest := methods.NewRPCTest(rpcURL, apiKey)
+
+	// Seed the accounts of recent blocks
+	return rpcTest.SeedAccountsFromRecentBlocks(seedBlocks, outputFile, limit)
 }
 
 func init() {
@@ -107,7 +250,15 @@ func init() {
 	// Add program-specific flags
 	seedCmd.Flags().StringArrayVarP(&programs, "program", "p", []string{}, "Program addresses to fetch accounts for (can be specified multiple times)")
 	seedCmd.Flags().StringVarP(&programsFile, "program-file", "f", "", "File containing program addresses (one per line)")
+	seedCmd.Flags().StringArrayVar(&programDiscriminators, "programs-discriminator", []string{}, "PROGRAM=VALUE keeping only the program's accounts starting with this discriminator (can be specified multiple times)")
+	seedCmd.Flags().IntVar(&discriminatorSize, "discriminator-size", defaultDiscriminatorSize, "Bytes of the --programs-discriminator values: 1, 2, 4 or 8 (anchor)")
 	seedCmd.Flags().StringVarP(&outputFile, "output", "o", "accounts.txt", "Output file to store account addresses")
+	seedCmd.Flags().StringVar(&sampleMode, "sample", methods.SampleHead, "Which accounts --limit keeps: head (the first N returned) or random (a uniform sample)")
+	seedCmd.Flags().Int64Var(&sampleSeed, "sample-seed", 0, "Seed for --sample random, printed so a sample can be reproduced (default: time-based)")
+	seedCmd.Flags().BoolVar(&failFast, "fail-fast", false, "Abort with a non-zero exit on the first program or owner that fails to seed")
+	seedCmd.Flags().StringArrayVar(&owners, "owner", []string{}, "Wallet addresses whose token accounts to seed with --token (can be specified multiple times)")
+	seedCmd.Flags().IntVar(&seedBlocks, "accounts-from-block", 0, "Seed the accounts touched by the transactions of this many recent blocks, most touched first, instead of program accounts")
+	seedCmd.Flags().BoolVar(&seedTokens, "token", false, "Seed the SPL token accounts of --owner wallets via getTokenAccountsByOwner instead of program accounts")
 
 	// Override the account-file flag to avoid confusion
 	seedCmd.Flags().StringVarP(&accountsFile, "account-file", "", "", "")
@@ -116,4 +267,6 @@ func init() {
 	// Override the account flag to avoid confusion
 	seedCmd.Flags().StringArrayVarP(&accounts, "account", "", []string{}, "")
 	seedCmd.Flags().MarkHidden("account")
+	seedCmd.Flags().Var(&accountList, "accounts", "")
+	seedCmd.Flags().MarkHidden("accounts")
 }
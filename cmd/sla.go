@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// slaThreshold is the --sla-threshold in milliseconds, successful requests within it count as SLA compliant
+var slaThreshold int
+
+// withinSLA reports whether a successful request counts as SLA compliant, false when --sla-threshold is off
+func withinSLA(latency time.Duration) bool {
+	return slaThreshold > 0 && latency <= time.Duration(slaThreshold)*time.Millisecond
+}
+
+// slaCompliance returns the percent of successful requests within --sla-threshold, ok is false when the
+// threshold is off or nothing succeeded
+func slaCompliance(result TestResult) (percent float64, ok bool) {
+	if slaThreshold <= 0 || result.SuccessCount == 0 {
+		return 0, false
+	}
+	return float64(result.SLACompliant) / float64(result.SuccessCount) * 100, true
+}
+
+// slaComplianceCell formats the compliance of a result for CSV, empty when it is off
+func slaComplianceCell(result TestResult) string {
+	percent, ok := slaCompliance(result)
+	if !ok {
+		return ""
+	}
+	return strconv.FormatFloat(percent, 'f', 2, 64)
+}
+
+// slaComplianceLabel formats the compliance of a result, e.g. "99.12% within 50ms", empty when it is off
+func slaComplianceLabel(result TestResult) string {
+	percent, ok := slaCompliance(result)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%.2f%% within %dms", percent, slaThreshold)
+}
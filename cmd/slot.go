@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"rpc_test/methods"
+)
+
+var (
+	// slotFlag pins account reads to a slot floor: a slot number, or latest to resolve one at start
+	slotFlag string
+
+	// pinnedSlot is the resolved --slot, 0 when reads are not pinned
+	pinnedSlot uint64
+)
+
+// slotLatest resolves --slot to the latest slot confirmed by every target
+const slotLatest = "latest"
+
+// resolveSlot sets pinnedSlot from --slot, resolving latest once to the lowest slot confirmed by targets,
+// so every endpoint compared can serve it
+func resolveSlot(targets ...string) error {
+	if slotFlag == "" {
+		return nil
+	}
+
+	if slotFlag != slotLatest {
+		slot, err := strconv.ParseUint(slotFlag, 10, 64)
+		if err != nil || slot == 0 {
+			return fmt.Errorf("invalid --slot %q, expected a slot number or %s", slotFlag, slotLatest)
+		}
+		pinnedSlot = slot
+	} else if pinnedSlot == 0 {
+		for _, target := range targets {
+			slot, err := methods.NewRPCTestWithOptions(target, apiKey, clientOptions()).LatestSlot(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to resolve --slot %s on %s: %v", slotLatest, redactURL(target), err)
+			}
+			if pinnedSlot == 0 || slot < pinnedSlot {
+				pinnedSlot = slot
+			}
+		}
+	}
+
+	fmt.Fprintf(output, "📌 Reading account state from slot %d or later (getAccountInfo, getMultipleAccounts)\n", pinnedSlot)
+	return nil
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"rpc_test/methods"
+)
+
+var (
+	// trackSlot measures how many slots behind the chain tip account reads are served
+	trackSlot bool
+
+	// maxSlotLag is the slot lag above which a response counts as stale under --track-slot
+	maxSlotLag uint64
+
+	// slotReference is the endpoint whose finalized slot is the chain tip, empty for the target itself
+	slotReference string
+)
+
+// slotTipInterval is how often the chain tip is polled, about one slot
+const slotTipInterval = 400 * time.Millisecond
+
+// slotTip follows the chain tip in the background while a method runs
+type slotTip struct {
+	rpcTest *methods.RPCTest
+	tip     atomic.Uint64
+}
+
+// startSlotTip follows the finalized slot of --slot-reference, or targetURL when it is not set, until stop
+// is closed, nil when --track-slot is off
+func startSlotTip(targetURL string, stop <-chan struct{}) *slotTip {
+	if !trackSlot {
+		return nil
+	}
+
+	reference := targetURL
+	if slotReference != "" {
+		reference = slotReference
+	}
+	t := &slotTip{rpcTest: methods.NewRPCTestWithOptions(reference, apiKey, clientOptions())}
+	t.poll()
+	go func() {
+		ticker := time.NewTicker(slotTipInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				t.poll()
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return t
+}
+
+// poll raises the tip to the reference's finalized slot, a failed poll keeps the last one
+func (t *slotTip) poll() {
+	slot, err := t.rpcTest.FinalizedSlot(context.Background())
+	if err != nil {
+		logWarn("slot_tip_failed", err)
+		return
+	}
+	for {
+		current := t.tip.Load()
+		if slot <= current || t.tip.CompareAndSwap(current, slot) {
+			return
+		}
+	}
+}
+
+// lag returns how many slots contextSlot trails the tip, ok is false when there is nothing to measure:
+// tracking is off, the tip is unknown or the response had no context
+func (t *slotTip) lag(contextSlot uint64) (lag uint64, ok bool) {
+	if t == nil || contextSlot == 0 {
+		return 0, false
+	}
+	tip := t.tip.Load()
+	if tip == 0 {
+		return 0, false
+	}
+	// The tip is polled, so a fresh response can be ahead of it
+	if contextSlot >= tip {
+		return 0, true
+	}
+	return tip - contextSlot, true
+}
+
+// slotLagSummary formats the slot lag of a result, e.g. "avg 1.4 slots, 3 stale (over 10)", empty when
+// nothing was measured
+func slotLagSummary(result TestResult) string {
+	if result.SlotLagSamples == 0 {
+		return ""
+	}
+	summary := fmt.Sprintf("avg %.1f slots", result.AvgSlotLag)
+	if result.StaleResponses > 0 {
+		summary += fmt.Sprintf(", %d stale (over %d)", result.StaleResponses, maxSlotLag)
+	}
+	return summary
+}
+
+// slotLagWarning explains a result whose average slot lag is over --max-slot-lag, empty when it isn't
+func slotLagWarning(result TestResult) string {
+	if result.SlotLagSamples == 0 || result.AvgSlotLag <= float64(maxSlotLag) {
+		return ""
+	}
+	return fmt.Sprintf("responses trail the chain tip by %.1f slots on average (--max-slot-lag %d), fast but stale", result.AvgSlotLag, maxSlotLag)
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// slowestCount is how many of the slowest requests --slowest reports, 0 to skip the report
+var slowestCount int
+
+// slowest keeps the slowest requests of the run, nil when --slowest is not set
+var slowest *slowestRequests
+
+// SlowRequest is one of the slowest requests of a run
+type SlowRequest struct {
+	Method    string    `json:"method"`
+	Account   string    `json:"account,omitempty"` // the account, or the first of a batch
+	Batch     int       `json:"batch,omitempty"`   // accounts in the batch, 0 for single-account methods
+	LatencyMs float64   `json:"latency_ms"`
+	At        time.Time `json:"at"`
+	Error     string    `json:"error,omitempty"`
+
+	latency time.Duration
+}
+
+// slowHeap is a min-heap on latency, so the fastest of the kept requests is the one replaced
+type slowHeap []SlowRequest
+
+func (h slowHeap) Len() int            { return len(h) }
+func (h slowHeap) Less(i, j int) bool  { return h[i].latency < h[j].latency }
+func (h slowHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *slowHeap) Push(x interface{}) { *h = append(*h, x.(SlowRequest)) }
+func (h *slowHeap) Pop() interface{} {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// slowestRequests keeps the --slowest slowest requests in a heap of fixed size
+type slowestRequests struct {
+	mu   sync.Mutex
+	size int
+	heap slowHeap
+}
+
+// startSlowest starts tracking the slowest requests when --slowest is set
+func startSlowest() {
+	if slowestCount <= 0 {
+		return
+	}
+	slowest = &slowestRequests{size: slowestCount, heap: make(slowHeap, 0, slowestCount)}
+}
+
+// record keeps the request when it is among the slowest so far; nil-safe
+func (s *slowestRequests) record(methodName string, args []string, start time.Time, latency time.Duration, err error) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.heap) == s.size && latency <= s.heap[0].latency {
+		return
+	}
+
+	request := SlowRequest{Method: methodName, LatencyMs: durationMs(latency), At: start, latency: latency}
+	if len(args) > 0 {
+		request.Account = args[0]
+	}
+	if batchMethods[methodName] {
+		request.Batch = len(args)
+	}
+	if err != nil {
+		request.Error = err.Error()
+	}
+
+	if len(s.heap) < s.size {
+		heap.Push(&s.heap, request)
+		return
+	}
+	s.heap[0] = request
+	heap.Fix(&s.heap, 0)
+}
+
+// list returns the kept requests, slowest first; nil-safe
+func (s *slowestRequests) list() []SlowRequest {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := append([]SlowRequest(nil), s.heap...)
+	sort.Slice(requests, func(i, j int) bool { return requests[i].latency > requests[j].latency })
+	return requests
+}
+
+// printSlowest prints the slowest requests of the run when --slowest is set
+func printSlowest() {
+	requests := slowest.list()
+	if len(requests) == 0 {
+		return
+	}
+
+	fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(output, "🐢 SLOWEST %d REQUESTS\n", len(requests))
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintf(output, "%-12s %-22s %-46s %s\n", "Latency", "Method", "Account", "At")
+	for _, request := range requests {
+		account := request.Account
+		if request.Batch > 1 {
+			account = fmt.Sprintf("%s +%d more", account, request.Batch-1)
+		}
+		if account == "" {
+			account = "-"
+		}
+		line := fmt.Sprintf("%-12s %-22s %-46s %s", formatLatency(request.latency), request.Method, account, request.At.Format("15:04:05.000"))
+		if request.Error != "" {
+			line += "  ❌ " + request.Error
+		}
+		fmt.Fprintln(output, line)
+	}
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+var (
+	// soakMode samples the tool's own memory and goroutines alongside rolling RPS/latency
+	soakMode     bool
+	soakInterval time.Duration
+)
+
+// soakDefaultDuration replaces the 10s --duration default in soak mode
+const soakDefaultDuration = 3600
+
+// soakSample is one --soak-interval of the run
+type soakSample struct {
+	At         time.Time
+	RPS        float64
+	P95        time.Duration
+	Failures   int64
+	HeapAlloc  uint64
+	Goroutines int
+}
+
+// soakMonitor collects rolling statistics from every worker of the process
+type soakMonitor struct {
+	mu        sync.Mutex
+	requests  int64
+	failures  int64
+	latencies []time.Duration
+	samples   []soakSample
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// soak is the running monitor, nil outside soak mode
+var soak *soakMonitor
+
+// startSoak starts sampling when --soak is set, defaulting --duration to an hour unless durationSet
+func startSoak(durationSet bool) error {
+	if !soakMode {
+		return nil
+	}
+	if soakInterval <= 0 {
+		return fmt.Errorf("--soak-interval must be positive")
+	}
+	if !durationSet {
+		duration = soakDefaultDuration
+	}
+
+	soak = &soakMonitor{stop: make(chan struct{}), done: make(chan struct{})}
+	fmt.Fprintf(output, "🧪 Soak mode: sampling memory, goroutines, RPS and p95 every %s\n", soakInterval)
+	go soak.run()
+	return nil
+}
+
+// stopSoak stops sampling and prints the soak report
+func stopSoak() {
+	if soak == nil {
+		return
+	}
+
+	close(soak.stop)
+	<-soak.done
+	soak.report()
+}
+
+// record adds a finished request to the current interval, nil-safe outside soak mode
+func (m *soakMonitor) record(latency time.Duration, err error) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests++
+	if err != nil {
+		m.failures++
+		return
+	}
+	m.latencies = append(m.latencies, latency)
+}
+
+// run takes a sample every --soak-interval until stopped
+func (m *soakMonitor) run() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(soakInterval)
+	defer ticker.Stop()
+
+	last := time.Now()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case now := <-ticker.C:
+			m.sample(now, now.Sub(last))
+			last = now
+		}
+	}
+}
+
+// sample closes the current interval, resetting the rolling counters so retention stays bounded
+func (m *soakMonitor) sample(now time.Time, elapsed time.Duration) {
+	m.mu.Lock()
+	requests, failures, latencies := m.requests, m.failures, m.latencies
+	m.requests, m.failures, m.latencies = 0, 0, nil
+	m.mu.Unlock()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	sample := soakSample{
+		At:         now,
+		RPS:        float64(requests) / elapsed.Seconds(),
+		P95:        percentile(latencies, 95),
+		Failures:   failures,
+		HeapAlloc:  mem.HeapAlloc,
+		Goroutines: runtime.NumGoroutine(),
+	}
+	m.samples = append(m.samples, sample)
+
+	fmt.Fprintf(output, "\n🧪 %s | RPS: %.1f | P95: %s | Failures: %d | Heap: %s | Goroutines: %d\n",
+		now.Format("15:04:05"), sample.RPS, formatLatency(sample.P95), sample.Failures, formatBytes(int64(sample.HeapAlloc)), sample.Goroutines)
+	logEvent("soak_sample",
+		"rps", sample.RPS,
+		"p95_ms", durationMs(sample.P95),
+		"failures", sample.Failures,
+		"heap_bytes", sample.HeapAlloc,
+		"goroutines", sample.Goroutines,
+	)
+}
+
+// growsMonotonically reports whether values never decrease and end higher than they started,
+// over at least 3 samples so a single step isn't mistaken for a trend
+func growsMonotonically(values []float64) bool {
+	if len(values) < 3 {
+		return false
+	}
+	for i := 1; i < len(values); i++ {
+		if values[i] < values[i-1] {
+			return false
+		}
+	}
+	return values[len(values)-1] > values[0]
+}
+
+// report prints how the endpoint and the tool itself changed over the soak
+func (m *soakMonitor) report() {
+	fmt.Fprintln(output, "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(output, "🧪 SOAK REPORT")
+	fmt.Fprintln(output, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if len(m.samples) == 0 {
+		fmt.Fprintf(output, "No samples taken, run for longer than --soak-interval %s\n", soakInterval)
+		return
+	}
+
+	first, last := m.samples[0], m.samples[len(m.samples)-1]
+	var heap, goroutines []float64
+	for _, sample := range m.samples {
+		heap = append(heap, float64(sample.HeapAlloc))
+		goroutines = append(goroutines, float64(sample.Goroutines))
+	}
+	heapLeak := growsMonotonically(heap)
+	goroutineLeak := growsMonotonically(goroutines)
+
+	fmt.Fprintf(output, "Samples:     %d over %s\n", len(m.samples), last.At.Sub(first.At).Round(time.Second))
+	fmt.Fprintf(output, "RPS:         %.1f → %.1f\n", first.RPS, last.RPS)
+	fmt.Fprintf(output, "P95:         %s → %s\n", formatLatency(first.P95), formatLatency(last.P95))
+	fmt.Fprintf(output, "Heap:        %s → %s\n", formatBytes(int64(first.HeapAlloc)), formatBytes(int64(last.HeapAlloc)))
+	fmt.Fprintf(output, "Goroutines:  %d → %d\n", first.Goroutines, last.Goroutines)
+
+	if heapLeak {
+		fmt.Fprintln(output, "⚠️  Heap grew in every sample, possible leak in the tool")
+	}
+	if goroutineLeak {
+		fmt.Fprintln(output, "⚠️  Goroutines grew in every sample, possible leak in the tool")
+	}
+	if !heapLeak && !goroutineLeak {
+		fmt.Fprintln(output, "✅ No steady heap or goroutine growth")
+	}
+
+	logEvent("soak_finished",
+		"samples", len(m.samples),
+		"first_rps", first.RPS,
+		"last_rps", last.RPS,
+		"first_p95_ms", durationMs(first.P95),
+		"last_p95_ms", durationMs(last.P95),
+		"heap_growing", heapLeak,
+		"goroutines_growing", goroutineLeak,
+	)
+}
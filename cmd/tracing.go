@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Span batching limits for the OTLP exporter
+const (
+	spanQueueSize     = 8192
+	spanBatchSize     = 512
+	spanFlushInterval = 5 * time.Second
+)
+
+// OTLP span kind and status codes (see opentelemetry-proto trace.proto)
+const (
+	otlpSpanKindClient = 3
+	otlpStatusOK       = 1
+	otlpStatusError    = 2
+)
+
+// tracer exports one span per RPC request when --otel-endpoint is set, nil otherwise
+var tracer *spanExporter
+
+// spanExporter batches request spans and ships them to an OTLP/HTTP collector as JSON
+type spanExporter struct {
+	endpoint string
+	target   string
+	client   *http.Client
+	spans    chan otlpSpan
+	done     chan struct{}
+	dropped  atomic.Int64
+}
+
+type otlpAnyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes"`
+	Status            otlpStatus      `json:"status"`
+}
+
+func stringAttribute(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func intAttribute(key string, value int64) otlpAttribute {
+	// OTLP JSON encodes 64-bit integers as strings
+	encoded := strconv.FormatInt(value, 10)
+	return otlpAttribute{Key: key, Value: otlpAnyValue{IntValue: &encoded}}
+}
+
+// startTracing creates the span exporter when --otel-endpoint is set
+func startTracing() error {
+	if otelEndpoint == "" {
+		return nil
+	}
+
+	endpoint, err := url.Parse(otelEndpoint)
+	if err != nil || endpoint.Host == "" {
+		return fmt.Errorf("invalid --otel-endpoint %q, expected e.g. http://localhost:4318", otelEndpoint)
+	}
+	if endpoint.Path == "" || endpoint.Path == "/" {
+		endpoint.Path = "/v1/traces"
+	}
+
+	tracer = &spanExporter{
+		endpoint: endpoint.String(),
+		target:   rpcURL,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		spans:    make(chan otlpSpan, spanQueueSize),
+		done:     make(chan struct{}),
+	}
+	go tracer.run()
+
+	fmt.Fprintf(output, "Exporting request spans to: %s\n", tracer.endpoint)
+	return nil
+}
+
+// stopTracing flushes any buffered spans and stops the exporter
+func stopTracing() {
+	if tracer == nil {
+		return
+	}
+
+	close(tracer.spans)
+	<-tracer.done
+
+	if dropped := tracer.dropped.Load(); dropped > 0 {
+		fmt.Fprintf(output, "⚠️  Dropped %d spans because the exporter queue was full\n", dropped)
+	}
+	tracer = nil
+}
+
+// record queues a span for a single request without blocking the worker
+func (e *spanExporter) record(method string, start time.Time, latency time.Duration, responseBytes int64, reqErr error) {
+	status := otlpStatus{Code: otlpStatusOK}
+	statusLabel := "ok"
+	if reqErr != nil {
+		status = otlpStatus{Code: otlpStatusError, Message: reqErr.Error()}
+		statusLabel = "error"
+	}
+
+	span := otlpSpan{
+		TraceID:           randomHex(16),
+		SpanID:            randomHex(8),
+		Name:              method,
+		Kind:              otlpSpanKindClient,
+		StartTimeUnixNano: strconv.FormatInt(start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(start.Add(latency).UnixNano(), 10),
+		Attributes: []otlpAttribute{
+			stringAttribute("rpc.system", "jsonrpc"),
+			stringAttribute("rpc.method", method),
+			stringAttribute("rpc.target", e.target),
+			stringAttribute("rpc.status", statusLabel),
+			intAttribute("rpc.latency_us", latency.Microseconds()),
+			intAttribute("rpc.response_bytes", responseBytes),
+		},
+		Status: status,
+	}
+
+	select {
+	case e.spans <- span:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+// run batches queued spans and exports them until the queue is closed
+func (e *spanExporter) run() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(spanFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]otlpSpan, 0, spanBatchSize)
+	for {
+		select {
+		case span, ok := <-e.spans:
+			if !ok {
+				e.export(batch)
+				return
+			}
+			batch = append(batch, span)
+			if len(batch) >= spanBatchSize {
+				e.export(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			e.export(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// export posts a batch of spans as an OTLP ExportTraceServiceRequest
+func (e *spanExporter) export(batch []otlpSpan) {
+	if len(batch) == 0 {
+		return
+	}
+
+	payload := map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": []otlpAttribute{stringAttribute("service.name", "rpc_test")},
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]string{"name": "rpc_test"},
+						"spans": batch,
+					},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Failed to encode spans: %v", err)
+		return
+	}
+
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Printf("Failed to export %d spans: %v", len(batch), err)
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		log.Printf("Collector rejected %d spans: %s", len(batch), resp.Status)
+	}
+}
+
+// randomHex returns n random bytes hex encoded, as OTLP JSON expects for trace and span IDs
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"rpc_test/methods"
+)
+
+// protocolBoth runs the same load over HTTP/1.1 and HTTP/2 and compares them
+const protocolBoth = "both"
+
+// compressionBoth runs the same load with and without gzip and compares them
+const compressionBoth = "both"
+
+// resolveProtocol validates the --protocol/--http2 flags and folds them into protocol
+func resolveProtocol() {
+	if connections < 0 {
+		log.Fatalf("--connections must not be negative, got %d", connections)
+	}
+
+	if forceHTTP2 {
+		if protocol != "" && protocol != methods.ProtocolAuto && protocol != methods.ProtocolHTTP2 {
+			log.Fatalf("--http2 conflicts with --protocol %s", protocol)
+		}
+		protocol = methods.ProtocolHTTP2
+	}
+
+	if protocol == protocolBoth {
+		return
+	}
+	if err := methods.ValidateProtocol(protocol); err != nil {
+		log.Fatalf("%v (or %s)", err, protocolBoth)
+	}
+}
+
+// resolveCompression validates the --compression flag
+func resolveCompression() {
+	if compression == compressionBoth {
+		if protocol == protocolBoth {
+			log.Fatalf("--compression both and --protocol both cannot be combined")
+		}
+		return
+	}
+	if err := methods.ValidateCompression(compression); err != nil {
+		log.Fatalf("%v (or %s)", err, compressionBoth)
+	}
+}
+
+// proxyCheckTimeout bounds the reachability probe made against --proxy
+const proxyCheckTimeout = 5 * time.Second
+
+// targetProxy is the parsed --proxy URL, nil when no proxy is configured
+var targetProxy *url.URL
+
+// resolveProxy validates --proxy and fails fast if the proxy cannot be reached
+func resolveProxy() {
+	if proxyAddr == "" {
+		return
+	}
+
+	parsed, err := methods.ParseProxyURL(proxyAddr)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := methods.CheckProxyReachable(parsed, proxyCheckTimeout); err != nil {
+		log.Fatalf("%v", err)
+	}
+	targetProxy = parsed
+}
+
+// proxyLabel describes the proxy for run headers with any credentials masked
+func proxyLabel() string {
+	if targetProxy == nil {
+		return "none"
+	}
+	return targetProxy.Redacted()
+}
+
+// clientOptions builds the transport options for the target RPC client from the command line flags
+func clientOptions() methods.ClientOptions {
+	opts := methods.ClientOptions{
+		Protocol:    protocol,
+		Compression: compression,
+		Proxy:       targetProxy,
+		UserAgent:   userAgent,
+
+		ConnectTimeout:   connectTimeout,
+		RequestTimeout:   requestTimeout,
+		Connections:      connections,
+		ReconnectOnError: reconnectOnError,
+	}
+	if protocol == protocolBoth {
+		opts.Protocol = ""
+	}
+	if compression == compressionBoth {
+		opts.Compression = ""
+	}
+	return opts
+}
+
+// connectionSummary describes how a run under --connections or --reconnect-on-error used its connections,
+// "" when neither was set
+func connectionSummary(result TestResult) string {
+	if (connections <= 0 && !reconnectOnError) || result.ConnectionsOpened == 0 {
+		return ""
+	}
+
+	summary := fmt.Sprintf("%d opened", result.ConnectionsOpened)
+	if connections > 0 {
+		summary += fmt.Sprintf(" (limit %d)", connections)
+	}
+	summary += fmt.Sprintf(", %.1f requests per connection", float64(result.TotalRequests)/float64(result.ConnectionsOpened))
+	if reconnectOnError {
+		summary += fmt.Sprintf(", %d forced reconnects", result.Reconnects)
+	}
+	if result.ConnectionWaits > 0 {
+		summary += fmt.Sprintf(", %d requests waited for a free connection (%s avg)",
+			result.ConnectionWaits, formatLatency(result.ConnectionWaitTime/time.Duration(result.ConnectionWaits)))
+	}
+	return summary
+}
+
+// compareCompression runs the same method load with and without gzip, reporting the delta
+func compareCompression(methodName string) {
+	plain, gzipped := clientOptions(), clientOptions()
+	plain.Compression = methods.CompressionNone
+	gzipped.Compression = methods.CompressionGzip
+
+	compareTransports(methodName, "🗜️  COMPRESSION COMPARISON", [2]transportVariant{
+		{Label: "none", Opts: plain},
+		{Label: "gzip", Opts: gzipped},
+	})
+}
+
+// protocolLabel describes the requested protocol for run headers
+func protocolLabel(protocol string) string {
+	switch protocol {
+	case methods.ProtocolHTTP1:
+		return "HTTP/1.1 (forced)"
+	case methods.ProtocolHTTP2:
+		return "HTTP/2 (forced)"
+	case protocolBoth:
+		return "HTTP/1.1 vs HTTP/2 comparison"
+	default:
+		return "auto (negotiated)"
+	}
+}
+
+// compareProtocols runs the same method load under HTTP/1.1 and then HTTP/2, reporting the delta
+func compareProtocols(methodName string) {
+	h1, h2 := clientOptions(), clientOptions()
+	h1.Protocol = methods.ProtocolHTTP1
+	h2.Protocol = methods.ProtocolHTTP2
+
+	compareTransports(methodName, "🔀 PROTOCOL COMPARISON", [2]transportVariant{
+		{Label: "HTTP/1.1", Opts: h1},
+		{Label: "HTTP/2", Opts: h2},
+	})
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+)
+
+// Supported values for --latency-unit
+const (
+	latencyUnitAuto = "auto"
+	latencyUnitUs   = "us"
+	latencyUnitMs   = "ms"
+	latencyUnitS    = "s"
+)
+
+// latencyUnit forces every latency in the report into one unit, auto picks one per value
+var latencyUnit string
+
+// validateLatencyUnit exits when --latency-unit is not a supported unit
+func validateLatencyUnit() {
+	switch latencyUnit {
+	case latencyUnitAuto, latencyUnitUs, latencyUnitMs, latencyUnitS:
+	default:
+		log.Fatalf("Invalid --latency-unit %q (expected %s, %s, %s or %s)", latencyUnit, latencyUnitAuto, latencyUnitUs, latencyUnitMs, latencyUnitS)
+	}
+}
+
+// tableLatencyUnit is the unit of latency columns in tabular outputs, milliseconds unless --latency-unit forces one
+func tableLatencyUnit() string {
+	if latencyUnit == latencyUnitAuto {
+		return latencyUnitMs
+	}
+	return latencyUnit
+}
+
+// latencyIn converts a latency to a fractional value in unit
+func latencyIn(d time.Duration, unit string) float64 {
+	switch unit {
+	case latencyUnitUs:
+		return float64(d) / float64(time.Microsecond)
+	case latencyUnitS:
+		return d.Seconds()
+	default:
+		return durationMs(d)
+	}
+}
+
+// formatForcedLatency formats a latency in the unit forced by --latency-unit
+func formatForcedLatency(d time.Duration) string {
+	switch latencyUnit {
+	case latencyUnitUs:
+		return fmt.Sprintf("%.*f μs", precision, latencyIn(d, latencyUnitUs))
+	case latencyUnitS:
+		return fmt.Sprintf("%.*f s", precision+1, latencyIn(d, latencyUnitS))
+	default:
+		return fmt.Sprintf("%.*f ms", precision, latencyIn(d, latencyUnitMs))
+	}
+}
+
+// maxPrecision is the most decimal places --precision accepts
+const maxPrecision = 6
+
+// precision is the decimal places of latencies, rates and percentages in the report
+var precision int
+
+// validatePrecision exits when --precision is out of range
+func validatePrecision() {
+	if precision < 0 || precision > maxPrecision {
+		log.Fatalf("Invalid --precision %d (expected 0 to %d)", precision, maxPrecision)
+	}
+}
+
+// formatDecimal formats a rate, percentage or duration in seconds with --precision decimal places
+func formatDecimal(v float64) string {
+	return strconv.FormatFloat(v, 'f', precision, 64)
+}
+
+// formatCount formats a count with thousands separators, e.g. 12,345,678
+func formatCount(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return sign + digits
+}
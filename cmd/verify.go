@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"rpc_test/methods"
+)
+
+var (
+	// verifyURLs are the endpoints whose getProgramAccounts results are checked against --url
+	verifyURLs []string
+
+	// verifyData hashes the account data as well as the pubkeys
+	verifyData bool
+)
+
+// programAccountFetch is one endpoint's enumeration of a program
+type programAccountFetch struct {
+	set     methods.ProgramAccountSet
+	latency time.Duration
+	err     error
+}
+
+// runVerification enumerates every program on --url and each --verify-against endpoint at the same time and
+// reports whether they returned the same accounts, exiting non-zero on any mismatch
+func runVerification() {
+	resolveProtocol()
+	resolveCompression()
+	resolveProxy()
+
+	targets := append([]string{rpcURL}, verifyURLs...)
+	for _, target := range verifyURLs {
+		if err := methods.ValidateRPCURL(target); err != nil {
+			log.Fatalf("Invalid --verify-against: %v", err)
+		}
+	}
+
+	hashed := "pubkeys"
+	if verifyData {
+		hashed = "pubkeys and data"
+	}
+	fmt.Fprintf(output, "Verifying %d programs across %d endpoints, hashing %s\n", len(programs), len(targets), hashed)
+
+	mismatches, unverified := 0, 0
+	for _, program := range programs {
+		fmt.Fprintf(output, "\n🔎 %s\n", program)
+
+		// Fetch from every endpoint at once so they are compared at nearly the same state
+		fetches := make([]programAccountFetch, len(targets))
+		var wg sync.WaitGroup
+		for i, target := range targets {
+			wg.Add(1)
+			go func(i int, target string) {
+				defer wg.Done()
+				rpcTest := methods.NewRPCTestWithOptions(target, apiKey, clientOptions())
+				applyProgramFilters(rpcTest)
+
+				start := time.Now()
+				set, err := rpcTest.FetchProgramAccountSet(context.Background(), program, verifyData)
+				fetches[i] = programAccountFetch{set: set, latency: time.Since(start), err: err}
+			}(i, target)
+		}
+		wg.Wait()
+
+		for i, fetch := range fetches {
+			if fetch.err != nil {
+				fmt.Fprintf(output, "   %-36s ❌ %v\n", redactURL(targets[i]), fetch.err)
+				continue
+			}
+			fmt.Fprintf(output, "   %-36s %8d accounts  %s  %s\n", redactURL(targets[i]), len(fetch.set.Pubkeys),
+				fetch.set.Hash, formatLatency(fetch.latency))
+		}
+
+		switch verdict := compareAccountSets(targets, fetches); verdict {
+		case "":
+			fmt.Fprintln(output, "   ✅ Match")
+		case errVerdict:
+			unverified++
+			fmt.Fprintln(output, "   ⚠️  Could not verify, not every endpoint answered")
+		default:
+			mismatches++
+			fmt.Fprintf(output, "   ❌ Mismatch: %s\n", verdict)
+		}
+	}
+
+	fmt.Fprintf(output, "\n🏁 %d of %d programs match", len(programs)-mismatches-unverified, len(programs))
+	if unverified > 0 {
+		fmt.Fprintf(output, ", %d could not be verified", unverified)
+	}
+	fmt.Fprintln(output)
+	if mismatches > 0 {
+		fmt.Fprintf(output, "❌ %d programs returned different accounts across endpoints\n", mismatches)
+		os.Exit(1)
+	}
+}
+
+// errVerdict is the compareAccountSets verdict when an endpoint failed
+const errVerdict = "error"
+
+// compareAccountSets returns an empty verdict when every endpoint returned the --url set, errVerdict when one
+// failed, and otherwise what each differing endpoint is missing or has extra compared to --url
+func compareAccountSets(targets []string, fetches []programAccountFetch) string {
+	for _, fetch := range fetches {
+		if fetch.err != nil {
+			return errVerdict
+		}
+	}
+
+	reference := make(map[string]bool, len(fetches[0].set.Pubkeys))
+	for _, pubkey := range fetches[0].set.Pubkeys {
+		reference[pubkey] = true
+	}
+
+	verdict := ""
+	for i, fetch := range fetches[1:] {
+		if fetch.set.Hash == fetches[0].set.Hash {
+			continue
+		}
+
+		extra := 0
+		for _, pubkey := range fetch.set.Pubkeys {
+			if !reference[pubkey] {
+				extra++
+			}
+		}
+		missing := len(fetches[0].set.Pubkeys) - (len(fetch.set.Pubkeys) - extra)
+
+		if verdict != "" {
+			verdict += "; "
+		}
+		if missing == 0 && extra == 0 {
+			verdict += fmt.Sprintf("%s has the same accounts with different data", redactURL(targets[i+1]))
+		} else {
+			verdict += fmt.Sprintf("%s is missing %d and has %d extra", redactURL(targets[i+1]), missing, extra)
+		}
+	}
+	return verdict
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// methodOffsets are the runall --method-offset METHOD=OFFSET values
+	methodOffsets []string
+
+	// methodOffsetOf is the parsed methodOffsets, methods without one start at --account-offset
+	methodOffsetOf map[string]int
+)
+
+// resolveMethodOffsets parses --method-offset and checks that every account method's window fits in accounts
+func resolveMethodOffsets(accounts []string) error {
+	suite := make(map[string]bool)
+	for _, method := range runallMethods {
+		suite[method] = true
+	}
+
+	methodOffsetOf = make(map[string]int)
+	for _, value := range methodOffsets {
+		method, rawOffset, ok := strings.Cut(value, "=")
+		if !ok {
+			return fmt.Errorf("invalid --method-offset %q, expected METHOD=OFFSET", value)
+		}
+		if !suite[method] {
+			return fmt.Errorf("invalid --method-offset %q: runall does not run %s", value, method)
+		}
+		if parameterlessMethods[method] {
+			return fmt.Errorf("invalid --method-offset %q: %s takes no accounts", value, method)
+		}
+		offset, err := strconv.Atoi(rawOffset)
+		if err != nil {
+			return fmt.Errorf("invalid --method-offset %q: %v", value, err)
+		}
+		methodOffsetOf[method] = offset
+	}
+
+	type window struct {
+		method     string
+		start, end int
+	}
+	var windows []window
+	for _, method := range runallMethods {
+		if parameterlessMethods[method] {
+			continue
+		}
+		offset := methodOffset(method)
+		list, err := accountWindow(accounts, offset, limit)
+		if err != nil {
+			return fmt.Errorf("invalid --method-offset for %s: %v", method, err)
+		}
+		fmt.Fprintf(output, "  🪟 %s reads accounts [%d:%d]\n", method, offset, offset+len(list))
+
+		current := window{method: method, start: offset, end: offset + len(list)}
+		for _, other := range windows {
+			if current.start < other.end && other.start < current.end {
+				fmt.Fprintf(output, "  ⚠️  %s and %s share accounts, their caches may interfere\n", other.method, method)
+			}
+		}
+		windows = append(windows, current)
+	}
+	return nil
+}
+
+// methodOffset returns where the method's window starts
+func methodOffset(methodName string) int {
+	if offset, ok := methodOffsetOf[methodName]; ok {
+		return offset
+	}
+	return accountOffset
+}
+
+// methodAccounts returns the accounts the method reads: its own window when --method-offset is in use,
+// the suite's accounts otherwise
+func methodAccounts(methodName string, accounts []string) []string {
+	if methodOffsetOf == nil || parameterlessMethods[methodName] {
+		return accounts
+	}
+
+	// resolveMethodOffsets already checked the window fits
+	window, _ := accountWindow(accounts, methodOffset(methodName), limit)
+	return window
+}
@@ -0,0 +1,88 @@
+package methods
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// IsGzipPath reports whether an account list at path is stored gzipped, judged by its .gz extension
+func IsGzipPath(path string) bool {
+	return strings.HasSuffix(path, ".gz")
+}
+
+// ReadAccountFile returns the non-empty lines of an account list, decompressing .gz files transparently.
+// Open errors are returned unwrapped so callers can check os.IsNotExist.
+func ReadAccountFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if IsGzipPath(path) {
+		// Reads every gzip member, so files appended to by repeated seeding runs load whole
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress %s: %v", path, err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	return lines, nil
+}
+
+// WriteAccountFile replaces the account list at path with accounts, one per line, gzipped for .gz paths.
+// The list is written to a temporary file first so a failed write leaves the old one intact.
+func WriteAccountFile(path string, accounts []string) error {
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %v", tmpPath, err)
+	}
+
+	var writer io.Writer = file
+	var gz *gzip.Writer
+	if IsGzipPath(path) {
+		gz = gzip.NewWriter(file)
+		writer = gz
+	}
+
+	buffered := bufio.NewWriter(writer)
+	for _, account := range accounts {
+		buffered.WriteString(account + "\n")
+	}
+	err = buffered.Flush()
+	if gz != nil && err == nil {
+		err = gz.Close()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %v", tmpPath, err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace %s: %v", path, err)
+	}
+	return nil
+}
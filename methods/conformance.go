@@ -0,0 +1,83 @@
+package methods
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// ConformanceError is a response that was accepted but does not have the shape the reference RPC
+// returns, only checked when CallOptions.Strict is set
+type ConformanceError struct {
+	Method  string
+	Field   string // path of the offending field in the result, e.g. value[3].data
+	Problem string
+}
+
+func (e *ConformanceError) Error() string {
+	return fmt.Sprintf("%s response does not conform: %s %s", e.Method, e.Field, e.Problem)
+}
+
+// AsConformanceError returns the conformance violation in err, nil when err is not one
+func AsConformanceError(err error) *ConformanceError {
+	var conformanceErr *ConformanceError
+	if errors.As(err, &conformanceErr) {
+		return conformanceErr
+	}
+	return nil
+}
+
+// strictDecode turns an error decoding the result into a violation, other errors are returned as is
+func strictDecode(method string, err error) error {
+	if !isDecodeError(err) {
+		return err
+	}
+	return &ConformanceError{Method: method, Field: "result", Problem: fmt.Sprintf("does not decode into the expected type (%v)", err)}
+}
+
+// strictContext checks the context every account response carries
+func strictContext(method string, context rpc.Context) error {
+	if context.Slot == 0 {
+		return &ConformanceError{Method: method, Field: "context.slot", Problem: "is null or 0"}
+	}
+	return nil
+}
+
+// strictAccount checks the fields of an existing account
+func strictAccount(method, path string, account *rpc.Account) error {
+	violation := func(field, problem string) error {
+		return &ConformanceError{Method: method, Field: path + "." + field, Problem: problem}
+	}
+
+	if account.Lamports == 0 {
+		return violation("lamports", "is null or 0 for an existing account")
+	}
+	if account.Data == nil {
+		return violation("data", "is null")
+	}
+	if account.Data.GetBinary() == nil && account.Data.GetRawJSON() == nil {
+		return violation("data", "is not an encoded [data, encoding] pair")
+	}
+	return nil
+}
+
+// strictKeyedAccounts checks every account of a getProgramAccounts response
+func strictKeyedAccounts(method string, accounts rpc.GetProgramAccountsResult) error {
+	for i, keyed := range accounts {
+		path := fmt.Sprintf("[%d]", i)
+		switch {
+		case keyed == nil:
+			return &ConformanceError{Method: method, Field: path, Problem: "is null"}
+		case keyed.Pubkey.Equals(solana.PublicKey{}):
+			return &ConformanceError{Method: method, Field: path + ".pubkey", Problem: "is null or the zero key"}
+		case keyed.Account == nil:
+			return &ConformanceError{Method: method, Field: path + ".account", Problem: "is null"}
+		}
+		if err := strictAccount(method, path+".account", keyed.Account); err != nil {
+			return err
+		}
+	}
+	return nil
+}
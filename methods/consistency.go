@@ -0,0 +1,62 @@
+package methods
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// ProgramAccountSet is what one getProgramAccounts call returned, reduced to something two endpoints can compare
+type ProgramAccountSet struct {
+	Pubkeys []string // sorted
+	Hash    string   // hex sha256 over the sorted pubkeys, followed by each account's data when it was fetched
+}
+
+// FetchProgramAccountSet enumerates the program's accounts and hashes them, only the pubkeys unless withData is
+// set, in which case the data is fetched and hashed too
+func (r *RPCTest) FetchProgramAccountSet(ctx context.Context, programAddress string, withData bool) (ProgramAccountSet, error) {
+	pubKey, err := solana.PublicKeyFromBase58(programAddress)
+	if err != nil {
+		return ProgramAccountSet{}, fmt.Errorf("invalid program address: %v", err)
+	}
+
+	opts := &rpc.GetProgramAccountsOpts{
+		Encoding: solana.EncodingBase64,
+		Filters:  r.programFilters[programAddress],
+	}
+	if !withData {
+		// A zero length dataSlice keeps the enumeration from transferring data that isn't hashed
+		offset, length := uint64(0), uint64(0)
+		opts.DataSlice = &rpc.DataSlice{Offset: &offset, Length: &length}
+	}
+
+	out, err := r.rpc.GetProgramAccountsWithOpts(withRPCMethod(ctx, "getProgramAccounts"), pubKey, opts)
+	if err != nil {
+		return ProgramAccountSet{}, fmt.Errorf("failed to get program accounts: %w", err)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Pubkey.String() < out[j].Pubkey.String()
+	})
+
+	set := ProgramAccountSet{Pubkeys: make([]string, 0, len(out))}
+	hash := sha256.New()
+	for _, keyed := range out {
+		set.Pubkeys = append(set.Pubkeys, keyed.Pubkey.String())
+		hash.Write(keyed.Pubkey.Bytes())
+		if withData && keyed.Account != nil && keyed.Account.Data != nil {
+			// Length prefixed so data can't shift across account boundaries and still hash the same
+			data := keyed.Account.Data.GetBinary()
+			hash.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(data))))
+			hash.Write(data)
+		}
+	}
+	set.Hash = hex.EncodeToString(hash.Sum(nil))
+	return set, nil
+}
@@ -0,0 +1,79 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// CallOptions are the per-method options of dispatched calls, the zero value sends each method's defaults
+type CallOptions struct {
+	CountOnly      bool               // getProgramAccounts: enumerate with a zero length dataSlice and count
+	StreamDecode   bool               // getProgramAccounts: count the accounts as the response streams in instead of decoding it whole
+	ValidateData   bool               // getMultipleAccounts: fail responses whose account data does not decode
+	Strict         bool               // account methods: fail responses that don't have the reference RPC's shape
+	MinContextSlot uint64             // getAccountInfo, getMultipleAccounts: serve state from at least this slot, 0 for any
+	RequireNonNull float64            // getMultipleAccounts: fail responses with fewer non-null accounts than this percent, 0 to accept any
+	InflationEpoch uint64             // getInflationReward: epoch to query, 0 for the last one
+	FeeMessage     string             // getFeeForMessage: base64 message to price
+	FeeCommitment  rpc.CommitmentType // getFeeForMessage: commitment to price at
+	RawMethod      string             // raw: JSON-RPC method to call
+	RawParams      []interface{}      // raw: params of RawMethod
+}
+
+// CallResult describes what a successful call returned beyond plain success
+type CallResult struct {
+	Empty   bool // the RPC served null: the account doesn't exist, the fee is unavailable at this commitment, or a raw call returned null
+	Partial bool // fewer non-null accounts came back than were requested
+	Counted int  // accounts a count only getProgramAccounts enumerated
+
+	ContextSlot uint64 // slot the response's context says the state is from, 0 for methods without a context
+}
+
+// SetCallOptions sets the options of the calls Dispatch sends with the client, call it before the client
+// is shared between workers
+func (r *RPCTest) SetCallOptions(opts CallOptions) {
+	r.callOptions = opts
+}
+
+// Dispatch sends one request of the registered method name, with args checked against its arity
+func Dispatch(ctx context.Context, name string, r *RPCTest, args ...string) (CallResult, error) {
+	spec, ok := LookupMethod(name)
+	if !ok {
+		return CallResult{}, fmt.Errorf("invalid method: %s", name)
+	}
+	if err := checkArity(spec, args); err != nil {
+		return CallResult{}, err
+	}
+	return spec.Call(ctx, r, args...)
+}
+
+// checkArity returns an error when args don't match what the method takes
+func checkArity(spec MethodSpec, args []string) error {
+	switch spec.Args {
+	case ArgsNone:
+		if len(args) > 0 {
+			return fmt.Errorf("%s takes no accounts, got %d", spec.Name, len(args))
+		}
+	case ArgsAccount:
+		if len(args) == 0 {
+			return fmt.Errorf("%s needs an account, got none", spec.Name)
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("%s takes one account, got %d", spec.Name, len(args))
+		}
+	case ArgsProgram:
+		if len(args) == 0 {
+			return fmt.Errorf("%s needs a program, got none", spec.Name)
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("%s takes one program, got %d", spec.Name, len(args))
+		}
+	case ArgsBatch:
+		if len(args) == 0 {
+			return fmt.Errorf("%s needs at least one account, got none", spec.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package methods
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDispatch(t *testing.T) {
+	contextResult := func(value string) string { return `{"context":{"slot":5},"value":` + value + `}` }
+
+	tests := []struct {
+		name   string
+		method string
+		args   []string
+		opts   CallOptions
+		result string
+		want   CallResult
+	}{
+		{"account", "getAccountInfo", []string{testAccountA}, CallOptions{}, contextResult(testAccountJSON), CallResult{ContextSlot: 5}},
+		// solana-go drops the context of a null account
+		{"missing account", "getAccountInfo", []string{testAccountA}, CallOptions{}, contextResult("null"), CallResult{Empty: true}},
+		{"batch", "getMultipleAccounts", []string{testAccountA, testAccountB}, CallOptions{},
+			contextResult("[" + testAccountJSON + "," + testAccountJSON + "]"), CallResult{ContextSlot: 5}},
+		{"program", "getProgramAccounts", []string{testProgram}, CallOptions{}, programAccountsJSON(testAccountA, testAccountB), CallResult{}},
+		{"count only", "getProgramAccounts", []string{testProgram}, CallOptions{CountOnly: true},
+			programAccountsJSON(testAccountA, testAccountB), CallResult{Counted: 2}},
+		{"stream decode", "getProgramAccounts", []string{testProgram}, CallOptions{StreamDecode: true},
+			programAccountsJSON(testAccountA, testAccountB), CallResult{Counted: 2}},
+		{"vote accounts", "getVoteAccounts", nil, CallOptions{}, `{"current":[],"delinquent":[]}`, CallResult{}},
+		{"cluster nodes", "getClusterNodes", nil, CallOptions{}, `[]`, CallResult{}},
+		{"largest accounts", "getLargestAccounts", nil, CallOptions{}, contextResult(`[]`), CallResult{}},
+		{"supply", "getSupply", nil, CallOptions{},
+			contextResult(`{"circulating":1,"nonCirculating":0,"nonCirculatingAccounts":[],"total":1}`), CallResult{}},
+		{"stake activation", "getStakeActivation", []string{testAccountA}, CallOptions{}, `{"active":0,"inactive":0,"state":"inactive"}`, CallResult{}},
+		{"inflation reward", "getInflationReward", []string{testAccountA, testAccountB}, CallOptions{}, `[null,null]`, CallResult{}},
+		{"fee", "getFeeForMessage", nil, CallOptions{FeeMessage: "AQAB"}, contextResult("5000"), CallResult{}},
+		{"no fee", "getFeeForMessage", nil, CallOptions{FeeMessage: "AQAB"}, contextResult("null"), CallResult{Empty: true}},
+		{"raw", "raw", nil, CallOptions{RawMethod: "getHealth"}, `"ok"`, CallResult{}},
+		{"raw null", "raw", nil, CallOptions{RawMethod: "getHealth"}, `null`, CallResult{Empty: true}},
+	}
+
+	// Every registered method needs a case
+	covered := make(map[string]bool)
+	for _, tt := range tests {
+		covered[tt.method] = true
+	}
+	for _, spec := range Registry {
+		if !covered[spec.Name] {
+			t.Errorf("no dispatch test for %s", spec.Name)
+		}
+	}
+
+	var mu sync.Mutex
+	var result string
+	var called []string
+	rpcTest := mockRPC(t, func(method string) string {
+		mu.Lock()
+		defer mu.Unlock()
+		called = append(called, method)
+		return result
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			result, called = tt.result, nil
+			mu.Unlock()
+			rpcTest.SetCallOptions(tt.opts)
+
+			got, err := Dispatch(context.Background(), tt.method, rpcTest, tt.args...)
+			if err != nil {
+				t.Fatalf("Dispatch: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+
+			// raw sends the method it is configured with
+			wantCall := tt.method
+			if tt.method == "raw" {
+				wantCall = tt.opts.RawMethod
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if len(called) != 1 || called[0] != wantCall {
+				t.Errorf("sent %v, want one %s request", called, wantCall)
+			}
+		})
+	}
+}
+
+func TestDispatchArity(t *testing.T) {
+	rpcTest := mockRPC(t, func(method string) string {
+		t.Errorf("unexpected %s request", method)
+		return "null"
+	})
+
+	tests := []struct {
+		method string
+		args   []string
+		want   string
+	}{
+		{"getAccountInfo", nil, "getAccountInfo needs an account, got none"},
+		{"getStakeActivation", nil, "getStakeActivation needs an account, got none"},
+		{"getAccountInfo", []string{testAccountA, testAccountB}, "getAccountInfo takes one account, got 2"},
+		{"getProgramAccounts", nil, "getProgramAccounts needs a program, got none"},
+		{"getProgramAccounts", []string{testProgram, testProgram}, "getProgramAccounts takes one program, got 2"},
+		{"getMultipleAccounts", nil, "getMultipleAccounts needs at least one account, got none"},
+		{"getInflationReward", nil, "getInflationReward needs at least one account, got none"},
+		{"getVoteAccounts", []string{testAccountA}, "getVoteAccounts takes no accounts, got 1"},
+		{"getSupply", []string{testAccountA, testAccountB}, "getSupply takes no accounts, got 2"},
+		{"getBlock", nil, "invalid method: getBlock"},
+	}
+	for _, tt := range tests {
+		_, err := Dispatch(context.Background(), tt.method, rpcTest, tt.args...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Dispatch(%s, %d args) = %v, want %q", tt.method, len(tt.args), err, tt.want)
+		}
+	}
+}
@@ -2,27 +2,47 @@ package methods
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
 )
 
-// GetAccountInfo fetches the account info for a given account address
-func (r *RPCTest) GetAccountInfo(accountAddress string) error {
+// GetAccountInfo fetches the account info for a given account address and reports whether the account exists
+// and the slot of the response's context
+func (r *RPCTest) GetAccountInfo(ctx context.Context, accountAddress string) (bool, uint64, error) {
 	// Parse the account address
 	pubKey, err := solana.PublicKeyFromBase58(accountAddress)
 	if err != nil {
-		return fmt.Errorf("invalid account address: %v", err)
+		return false, 0, fmt.Errorf("invalid account address: %v", err)
 	}
 
 	// Fetch account info
-	_, err = r.rpc.GetAccountInfo(
-		context.Background(),
+	out, err := r.rpc.GetAccountInfoWithOpts(
+		withRPCMethod(ctx, "getAccountInfo"),
 		pubKey,
+		&rpc.GetAccountInfoOpts{Encoding: solana.EncodingBase64, MinContextSlot: r.minContextSlot()},
 	)
+	if errors.Is(err, rpc.ErrNotFound) {
+		// The RPC served a null value, the request itself succeeded, solana-go drops its context
+		return false, 0, nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get account info: %v", err)
+		if r.callOptions.Strict {
+			err = strictDecode("getAccountInfo", err)
+		}
+		return false, 0, fmt.Errorf("failed to get account info: %w", err)
+	}
+
+	if r.callOptions.Strict {
+		if err := strictContext("getAccountInfo", out.Context); err != nil {
+			return false, 0, err
+		}
+		if err := strictAccount("getAccountInfo", "value", out.Value); err != nil {
+			return false, 0, err
+		}
 	}
 
-	return nil
+	return true, out.Context.Slot, nil
 }
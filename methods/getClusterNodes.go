@@ -0,0 +1,18 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetClusterNodes fetches information about all the nodes participating in the cluster
+func (r *RPCTest) GetClusterNodes(ctx context.Context) error {
+	_, err := r.rpc.GetClusterNodes(
+		withRPCMethod(ctx, "getClusterNodes"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster nodes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package methods
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// sampleFeePayer pays for the sample transfer, fee lookups don't check its balance
+var sampleFeePayer = solana.MustPublicKeyFromBase58("vines1vzrYbzLMRdu58ou5XTby4qAqVRLmqo36NKPTg")
+
+// GetFeeForMessage looks up the fee of a base64 encoded message and reports whether the node could price it
+func (r *RPCTest) GetFeeForMessage(ctx context.Context, base64Msg string, commitment rpc.CommitmentType) (bool, error) {
+	result, err := r.rpc.GetFeeForMessage(
+		withRPCMethod(ctx, "getFeeForMessage"),
+		base64Msg,
+		commitment,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to get fee for message: %w", err)
+	}
+
+	// A null value means the blockhash is unknown at this commitment, typically because it expired
+	return result.Value != nil, nil
+}
+
+// SampleFeeMessage builds a base64 encoded 1 lamport self-transfer against the latest blockhash
+func (r *RPCTest) SampleFeeMessage(ctx context.Context, commitment rpc.CommitmentType) (string, error) {
+	latest, err := r.rpc.GetLatestBlockhash(
+		withRPCMethod(ctx, "getLatestBlockhash"),
+		commitment,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to get latest blockhash: %w", err)
+	}
+
+	// Legacy message: header, account keys, recent blockhash, instructions (lengths are compact-u16, all < 128 here)
+	msg := []byte{
+		1, // required signatures: the fee payer
+		0, // read-only signed accounts
+		1, // read-only unsigned accounts: the system program
+		2, // account keys
+	}
+	msg = append(msg, sampleFeePayer.Bytes()...)
+	msg = append(msg, solana.SystemProgramID.Bytes()...)
+	msg = append(msg, latest.Value.Blockhash[:]...)
+
+	// System program transfer (instruction 2) of 1 lamport from the payer to itself
+	data := binary.LittleEndian.AppendUint32(nil, 2)
+	data = binary.LittleEndian.AppendUint64(data, 1)
+	msg = append(msg,
+		1,    // instructions
+		1,    // program ID index
+		2,    // account indexes
+		0, 0, // from, to
+		byte(len(data)),
+	)
+	msg = append(msg, data...)
+
+	return base64.StdEncoding.EncodeToString(msg), nil
+}
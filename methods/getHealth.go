@@ -0,0 +1,32 @@
+package methods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+)
+
+// methodNotFoundCode is the JSON-RPC error code of a method the endpoint doesn't implement
+const methodNotFoundCode = -32601
+
+// CheckHealth returns nil once the node is serving: getHealth reports ok, or getSlot answers on
+// endpoints that don't implement getHealth
+func (r *RPCTest) CheckHealth(ctx context.Context) error {
+	_, err := r.rpc.GetHealth(withRPCMethod(ctx, "getHealth"))
+
+	var rpcErr *jsonrpc.RPCError
+	if errors.As(err, &rpcErr) && rpcErr.Code == methodNotFoundCode {
+		if _, err := r.rpc.GetSlot(withRPCMethod(ctx, "getSlot"), rpc.CommitmentProcessed); err != nil {
+			return fmt.Errorf("failed to get slot: %v", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("node is not healthy: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// GetInflationReward fetches the inflation rewards of a batch of addresses, epoch 0 means the last completed epoch
+func (r *RPCTest) GetInflationReward(ctx context.Context, epoch uint64, addresses ...string) error {
+	// Parse the addresses
+	pubKeys := make([]solana.PublicKey, 0, len(addresses))
+	for _, addrStr := range addresses {
+		addrStr = strings.TrimSpace(addrStr)
+		if addrStr == "" {
+			continue
+		}
+
+		pubKey, err := solana.PublicKeyFromBase58(addrStr)
+		if err != nil {
+			return fmt.Errorf("invalid address '%s': %v", addrStr, err)
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	if len(pubKeys) == 0 {
+		return fmt.Errorf("no valid addresses provided")
+	}
+
+	opts := &rpc.GetInflationRewardOpts{}
+	if epoch > 0 {
+		opts.Epoch = &epoch
+	}
+
+	// Fetch inflation rewards
+	_, err := r.rpc.GetInflationReward(
+		withRPCMethod(ctx, "getInflationReward"),
+		pubKeys,
+		opts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get inflation reward: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetLargestAccounts fetches the 20 largest accounts by lamport balance
+func (r *RPCTest) GetLargestAccounts(ctx context.Context) error {
+	// Empty commitment and filter leave both to the node's defaults
+	_, err := r.rpc.GetLargestAccounts(
+		withRPCMethod(ctx, "getLargestAccounts"),
+		"",
+		"",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get largest accounts: %w", err)
+	}
+
+	return nil
+}
@@ -2,14 +2,19 @@ package methods
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
 )
 
-// GetMultipleAccounts fetches information for multiple accounts at once
-func (r *RPCTest) GetMultipleAccounts(accountsStr ...string) error {
+// GetMultipleAccounts fetches information for multiple accounts at once and reports whether
+// every requested account came back non-null and the slot of the response's context
+func (r *RPCTest) GetMultipleAccounts(ctx context.Context, accountsStr ...string) (bool, uint64, error) {
 
 	// Parse the account addresses
 	pubKeys := make([]solana.PublicKey, 0, len(accountsStr))
@@ -21,24 +26,97 @@ func (r *RPCTest) GetMultipleAccounts(accountsStr ...string) error {
 
 		pubKey, err := solana.PublicKeyFromBase58(addrStr)
 		if err != nil {
-			return fmt.Errorf("invalid account address '%s': %v", addrStr, err)
+			return false, 0, fmt.Errorf("invalid account address '%s': %v", addrStr, err)
 		}
 		pubKeys = append(pubKeys, pubKey)
 	}
 
 	if len(pubKeys) == 0 {
-		return fmt.Errorf("no valid account addresses provided")
+		return false, 0, fmt.Errorf("no valid account addresses provided")
 	}
 
 	// Fetch multiple accounts
-	_, err := r.rpc.GetMultipleAccounts(
-		context.Background(),
-		pubKeys...,
+	result, err := r.rpc.GetMultipleAccountsWithOpts(
+		withRPCMethod(ctx, "getMultipleAccounts"),
+		pubKeys,
+		&rpc.GetMultipleAccountsOpts{Encoding: solana.EncodingBase64, MinContextSlot: r.minContextSlot()},
 	)
-	
 	if err != nil {
-		return fmt.Errorf("failed to get multiple accounts: %v", err)
+		if r.callOptions.Strict {
+			err = strictDecode("getMultipleAccounts", err)
+		} else if r.callOptions.ValidateData && isDecodeError(err) {
+			return false, 0, fmt.Errorf("failed to get multiple accounts: %w: %v", ErrMalformedData, err)
+		}
+		return false, 0, fmt.Errorf("failed to get multiple accounts: %w", err)
+	}
+
+	if r.callOptions.Strict {
+		if err := strictContext("getMultipleAccounts", result.Context); err != nil {
+			return false, 0, err
+		}
+		if len(result.Value) != len(pubKeys) {
+			return false, 0, &ConformanceError{Method: "getMultipleAccounts", Field: "value",
+				Problem: fmt.Sprintf("has %d entries for %d requested accounts", len(result.Value), len(pubKeys))}
+		}
+	}
+
+	// Under load some endpoints return a truncated array or nulls for accounts that exist
+	returned := 0
+	for i, account := range result.Value {
+		if account == nil {
+			continue
+		}
+		returned++
+
+		if r.callOptions.Strict {
+			if err := strictAccount("getMultipleAccounts", fmt.Sprintf("value[%d]", i), account); err != nil {
+				return false, 0, err
+			}
+		}
+		if r.callOptions.ValidateData {
+			if err := validateAccountData(account); err != nil {
+				return false, 0, fmt.Errorf("%w: account %d of the response: %v", ErrMalformedData, i, err)
+			}
+		}
+	}
+
+	if required := r.callOptions.RequireNonNull; required > 0 && float64(returned)*100 < required*float64(len(pubKeys)) {
+		return false, 0, fmt.Errorf("%w: %d of %d accounts non-null, %.2f%% required", ErrTooManyNulls, returned, len(pubKeys), required)
 	}
 
+	return returned == len(pubKeys), result.Context.Slot, nil
+}
+
+// validateAccountData checks that an account decoded into usable data
+func validateAccountData(account *rpc.Account) error {
+	if account.Data == nil {
+		return fmt.Errorf("no data")
+	}
+	if account.Data.GetBinary() == nil && account.Data.GetRawJSON() == nil {
+		return fmt.Errorf("data did not decode")
+	}
 	return nil
 }
+
+// isDecodeError reports whether err came from decoding a response rather than from the endpoint or the network
+func isDecodeError(err error) bool {
+	if ClassifyError(err) != ErrorKindOther {
+		return false
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var base64Err base64.CorruptInputError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.As(err, &base64Err) {
+		return true
+	}
+
+	// The JSON decoder solana-go uses does not return the encoding/json types, so fall back to the message
+	msg := strings.ToLower(err.Error())
+	for _, hint := range []string{"unmarshal", "decode", "base64", "base58"} {
+		if strings.Contains(msg, hint) {
+			return true
+		}
+	}
+	return false
+}
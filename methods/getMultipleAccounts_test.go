@@ -0,0 +1,69 @@
+package methods
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGetMultipleAccountsValidateData(t *testing.T) {
+	tests := []struct {
+		name      string
+		account   string
+		malformed bool
+	}{
+		{"well formed", testAccountJSON, false},
+		{"data not base64", `{"data":["@@@not base64@@@","base64"],"executable":false,"lamports":1000,"owner":"11111111111111111111111111111111","rentEpoch":0}`, true},
+		{"owner not a pubkey", `{"data":["AAEC","base64"],"executable":false,"lamports":1000,"owner":"not-a-pubkey","rentEpoch":0}`, true},
+		{"data missing", `{"data":null,"executable":false,"lamports":1000,"owner":"11111111111111111111111111111111","rentEpoch":0}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpcTest := mockRPC(t, func(string) string {
+				return `{"context":{"slot":5},"value":[` + tt.account + `]}`
+			})
+			rpcTest.SetCallOptions(CallOptions{ValidateData: true})
+
+			_, _, err := rpcTest.GetMultipleAccounts(context.Background(), testAccountA)
+			if tt.malformed != errors.Is(err, ErrMalformedData) {
+				t.Fatalf("got error %v, want malformed %v", err, tt.malformed)
+			}
+			if !tt.malformed && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetMultipleAccountsPartial(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		partial bool
+	}{
+		{"complete", `[` + testAccountJSON + `,` + testAccountJSON + `]`, false},
+		{"truncated array", `[` + testAccountJSON + `]`, true},
+		{"null account", `[` + testAccountJSON + `,null]`, true},
+		{"empty array", `[]`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpcTest := mockRPC(t, func(string) string {
+				return `{"context":{"slot":5},"value":` + tt.value + `}`
+			})
+
+			outcome, err := Dispatch(context.Background(), "getMultipleAccounts", rpcTest, testAccountA, testAccountB)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if outcome.Partial != tt.partial {
+				t.Fatalf("Partial = %v, want %v", outcome.Partial, tt.partial)
+			}
+			if outcome.ContextSlot != 5 {
+				t.Fatalf("ContextSlot = %d, want 5", outcome.ContextSlot)
+			}
+		})
+	}
+}
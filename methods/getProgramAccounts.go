@@ -5,24 +5,67 @@ import (
 	"fmt"
 
 	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
 )
 
 // GetProgramAccounts fetches accounts owned by the program
-func (r *RPCTest) GetProgramAccounts(programAddress string) error {
+func (r *RPCTest) GetProgramAccounts(ctx context.Context, programAddress string) error {
 	// Parse the program address
 	pubKey, err := solana.PublicKeyFromBase58(programAddress)
 	if err != nil {
 		return fmt.Errorf("invalid program address: %v", err)
 	}
 
-	// Fetch program accounts
-	_, err = r.rpc.GetProgramAccounts(
-		context.Background(),
+	// Fetch program accounts, restricted to the account type of its discriminator when one is set
+	out, err := r.rpc.GetProgramAccountsWithOpts(
+		withRPCMethod(ctx, "getProgramAccounts"),
 		pubKey,
+		r.programAccountsOpts(programAddress),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to get program accounts: %v", err)
+		if r.callOptions.Strict {
+			err = strictDecode("getProgramAccounts", err)
+		}
+		return fmt.Errorf("failed to get program accounts: %w", err)
+	}
+
+	if r.callOptions.Strict {
+		return strictKeyedAccounts("getProgramAccounts", out)
 	}
 
 	return nil
 }
+
+// CountProgramAccounts enumerates the accounts owned by the program with a zero length dataSlice,
+// so no account data is transferred, and returns how many there are
+func (r *RPCTest) CountProgramAccounts(ctx context.Context, programAddress string) (int, error) {
+	pubKey, err := solana.PublicKeyFromBase58(programAddress)
+	if err != nil {
+		return 0, fmt.Errorf("invalid program address: %v", err)
+	}
+
+	offset, length := uint64(0), uint64(0)
+	out, err := r.rpc.GetProgramAccountsWithOpts(
+		withRPCMethod(ctx, "getProgramAccounts"),
+		pubKey,
+		&rpc.GetProgramAccountsOpts{
+			Encoding:  solana.EncodingBase64,
+			DataSlice: &rpc.DataSlice{Offset: &offset, Length: &length},
+			Filters:   r.programFilters[programAddress],
+		},
+	)
+	if err != nil {
+		if r.callOptions.Strict {
+			err = strictDecode("getProgramAccounts", err)
+		}
+		return 0, fmt.Errorf("failed to count program accounts: %w", err)
+	}
+
+	if r.callOptions.Strict {
+		if err := strictKeyedAccounts("getProgramAccounts", out); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(out), nil
+}
@@ -0,0 +1,21 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// GetRecentPerformanceSamples fetches the node's self-reported performance samples, newest first
+func (r *RPCTest) GetRecentPerformanceSamples(ctx context.Context, limit uint) ([]*rpc.GetRecentPerformanceSamplesResult, error) {
+	samples, err := r.rpc.GetRecentPerformanceSamples(
+		withRPCMethod(ctx, "getRecentPerformanceSamples"),
+		&limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent performance samples: %v", err)
+	}
+
+	return samples, nil
+}
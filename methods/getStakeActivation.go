@@ -0,0 +1,30 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// GetStakeActivation fetches the activation state of a stake account
+func (r *RPCTest) GetStakeActivation(ctx context.Context, stakeAccount string) error {
+	// Parse the stake account address
+	pubKey, err := solana.PublicKeyFromBase58(stakeAccount)
+	if err != nil {
+		return fmt.Errorf("invalid stake account address: %v", err)
+	}
+
+	// Fetch stake activation for the current epoch
+	_, err = r.rpc.GetStakeActivation(
+		withRPCMethod(ctx, "getStakeActivation"),
+		pubKey,
+		"",
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get stake activation: %w", err)
+	}
+
+	return nil
+}
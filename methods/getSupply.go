@@ -0,0 +1,19 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetSupply fetches information about the current token supply
+func (r *RPCTest) GetSupply(ctx context.Context) error {
+	_, err := r.rpc.GetSupply(
+		withRPCMethod(ctx, "getSupply"),
+		"",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get supply: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetVoteAccounts fetches the current and delinquent vote accounts of the cluster
+func (r *RPCTest) GetVoteAccounts(ctx context.Context) error {
+	_, err := r.rpc.GetVoteAccounts(
+		withRPCMethod(ctx, "getVoteAccounts"),
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get vote accounts: %w", err)
+	}
+
+	return nil
+}
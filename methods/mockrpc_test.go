@@ -0,0 +1,47 @@
+package methods
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// mockRPC starts a JSON-RPC endpoint that answers each call with the raw JSON result returned by respond
+// for its method, and returns a client of it
+func mockRPC(t testing.TB, respond func(method string) string) *RPCTest {
+	t.Helper()
+	return mockRPCResponse(t, func(method string) string { return `"result":` + respond(method) })
+}
+
+// mockRPCResponse is mockRPC with respond returning the response member after the id, a "result" or an "error"
+func mockRPCResponse(t testing.TB, respond func(method string) string) *RPCTest {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,%s}`, request.ID, respond(request.Method))
+	}))
+	t.Cleanup(server.Close)
+
+	return NewRPCTest(server.URL, "")
+}
+
+// Addresses that are valid pubkeys for requests to a mock
+const (
+	testAccountA = "SysvarRent111111111111111111111111111111111"
+	testAccountB = "SysvarC1ock11111111111111111111111111111111"
+	testProgram  = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+)
+
+// testAccountJSON is a well-formed account with 3 bytes of base64 data
+const testAccountJSON = `{"data":["AAEC","base64"],"executable":false,"lamports":1000,"owner":"11111111111111111111111111111111","rentEpoch":0}`
@@ -0,0 +1,167 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// Page strategies of PaginateProgramAccounts
+const (
+	// PageByMemcmp issues one call per value of the byte at PageOptions.Offset, 256 pages
+	PageByMemcmp = "memcmp"
+	// PageByDataSize issues one call per account size in PageOptions.DataSizes
+	PageByDataSize = "datasize"
+	// PageByKeys lists the pubkeys with a zero length dataSlice, then fetches the data in pages of PageOptions.PageSize
+	PageByKeys = "keys"
+)
+
+// PageStrategies lists the supported page strategies
+var PageStrategies = []string{PageByMemcmp, PageByDataSize, PageByKeys}
+
+// maxKeysPageSize is the most accounts a getMultipleAccounts call accepts
+const maxKeysPageSize = 100
+
+// PageOptions configures how PaginateProgramAccounts splits a program into pages
+type PageOptions struct {
+	Strategy  string
+	Offset    uint64
+	DataSizes []uint64
+	PageSize  int
+}
+
+// Validate reports whether the options describe a usable pagination
+func (o PageOptions) Validate() error {
+	switch o.Strategy {
+	case PageByMemcmp:
+	case PageByDataSize:
+		if len(o.DataSizes) == 0 {
+			return fmt.Errorf("the %s strategy needs at least one account size", PageByDataSize)
+		}
+	case PageByKeys:
+		if o.PageSize < 1 || o.PageSize > maxKeysPageSize {
+			return fmt.Errorf("page size %d out of range (1-%d)", o.PageSize, maxKeysPageSize)
+		}
+	default:
+		return fmt.Errorf("unknown page strategy %q (expected one of %v)", o.Strategy, PageStrategies)
+	}
+	return nil
+}
+
+// PageResult is the outcome of fetching one page
+type PageResult struct {
+	Label    string
+	Accounts int
+	Latency  time.Duration
+	Err      error
+}
+
+// PaginateProgramAccounts enumerates every account of the program page by page, calling onPage after each
+// page so the caller can time the pages as they complete. It stops at the first page that fails.
+func (r *RPCTest) PaginateProgramAccounts(ctx context.Context, programAddress string, opts PageOptions, onPage func(PageResult)) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	pubKey, err := solana.PublicKeyFromBase58(programAddress)
+	if err != nil {
+		return fmt.Errorf("invalid program address: %v", err)
+	}
+
+	if opts.Strategy == PageByKeys {
+		return r.paginateByKeys(ctx, pubKey, opts.PageSize, onPage)
+	}
+
+	var pages []rpc.RPCFilter
+	var labels []string
+	switch opts.Strategy {
+	case PageByMemcmp:
+		for value := 0; value < 256; value++ {
+			pages = append(pages, rpc.RPCFilter{
+				Memcmp: &rpc.RPCFilterMemcmp{Offset: opts.Offset, Bytes: solana.Base58([]byte{byte(value)})},
+			})
+			labels = append(labels, fmt.Sprintf("byte[%d]=0x%02x", opts.Offset, value))
+		}
+	case PageByDataSize:
+		for _, size := range opts.DataSizes {
+			pages = append(pages, rpc.RPCFilter{DataSize: size})
+			labels = append(labels, fmt.Sprintf("dataSize=%d", size))
+		}
+	}
+
+	for i, page := range pages {
+		// The page filter narrows whatever type filter is already set for the program
+		filters := append(append([]rpc.RPCFilter(nil), r.programFilters[programAddress]...), page)
+
+		start := time.Now()
+		out, err := r.rpc.GetProgramAccountsWithOpts(
+			withRPCMethod(ctx, "getProgramAccounts"),
+			pubKey,
+			&rpc.GetProgramAccountsOpts{Filters: filters},
+		)
+		result := PageResult{Label: labels[i], Latency: time.Since(start), Accounts: len(out)}
+		if err != nil {
+			result.Err = fmt.Errorf("failed to get page %s: %w", labels[i], err)
+		}
+		onPage(result)
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+
+	return nil
+}
+
+// paginateByKeys lists the program's pubkeys without data, then fetches the accounts in pages of pageSize
+func (r *RPCTest) paginateByKeys(ctx context.Context, pubKey solana.PublicKey, pageSize int, onPage func(PageResult)) error {
+	offset, length := uint64(0), uint64(0)
+
+	start := time.Now()
+	keyed, err := r.rpc.GetProgramAccountsWithOpts(
+		withRPCMethod(ctx, "getProgramAccounts"),
+		pubKey,
+		&rpc.GetProgramAccountsOpts{
+			Encoding:  solana.EncodingBase64,
+			DataSlice: &rpc.DataSlice{Offset: &offset, Length: &length},
+			Filters:   r.programFilters[pubKey.String()],
+		},
+	)
+	result := PageResult{Label: "keys", Latency: time.Since(start), Accounts: len(keyed)}
+	if err != nil {
+		result.Err = fmt.Errorf("failed to list program keys: %w", err)
+	}
+	onPage(result)
+	if result.Err != nil {
+		return result.Err
+	}
+
+	for from := 0; from < len(keyed); from += pageSize {
+		to := min(from+pageSize, len(keyed))
+		keys := make([]solana.PublicKey, 0, to-from)
+		for _, account := range keyed[from:to] {
+			keys = append(keys, account.Pubkey)
+		}
+
+		start := time.Now()
+		out, err := r.rpc.GetMultipleAccounts(withRPCMethod(ctx, "getMultipleAccounts"), keys...)
+		result := PageResult{Label: fmt.Sprintf("accounts[%d:%d]", from, to), Latency: time.Since(start)}
+		if err != nil {
+			result.Err = fmt.Errorf("failed to get page %s: %w", result.Label, err)
+		} else {
+			for _, account := range out.Value {
+				if account != nil {
+					result.Accounts++
+				}
+			}
+		}
+		onPage(result)
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+
+	return nil
+}
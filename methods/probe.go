@@ -0,0 +1,51 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// LiveAccounts returns the accounts that exist on the endpoint, in their original order, checked with
+// getMultipleAccounts batches of batchSize. A zero length dataSlice keeps the responses small.
+func (r *RPCTest) LiveAccounts(ctx context.Context, accounts []string, batchSize int) ([]string, error) {
+	offset, length := uint64(0), uint64(0)
+	live := make([]string, 0, len(accounts))
+
+	for from := 0; from < len(accounts); from += batchSize {
+		batch := accounts[from:min(from+batchSize, len(accounts))]
+		keys := make([]solana.PublicKey, 0, len(batch))
+		for _, account := range batch {
+			key, err := solana.PublicKeyFromBase58(account)
+			if err != nil {
+				return nil, fmt.Errorf("invalid account address '%s': %v", account, err)
+			}
+			keys = append(keys, key)
+		}
+
+		out, err := r.rpc.GetMultipleAccountsWithOpts(
+			withRPCMethod(ctx, "getMultipleAccounts"),
+			keys,
+			&rpc.GetMultipleAccountsOpts{
+				Encoding:  solana.EncodingBase64,
+				DataSlice: &rpc.DataSlice{Offset: &offset, Length: &length},
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to probe accounts[%d:%d]: %w", from, from+len(batch), err)
+		}
+		if len(out.Value) != len(batch) {
+			return nil, fmt.Errorf("failed to probe accounts[%d:%d]: %d entries for %d accounts", from, from+len(batch), len(out.Value), len(batch))
+		}
+
+		for i, account := range out.Value {
+			if account != nil {
+				live = append(live, batch[i])
+			}
+		}
+	}
+
+	return live, nil
+}
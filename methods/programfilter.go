@@ -0,0 +1,46 @@
+package methods
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// DiscriminatorFilter matches accounts whose first width bytes are value in little-endian order,
+// e.g. the 8 byte discriminator anchor writes at the start of every account of one type
+func DiscriminatorFilter(value uint64, width int) (rpc.RPCFilter, error) {
+	switch width {
+	case 1, 2, 4, 8:
+	default:
+		return rpc.RPCFilter{}, fmt.Errorf("invalid discriminator size %d (expected 1, 2, 4 or 8 bytes)", width)
+	}
+	if width < 8 && value >= 1<<(8*width) {
+		return rpc.RPCFilter{}, fmt.Errorf("discriminator %d does not fit in %d bytes", value, width)
+	}
+
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, value)
+	return rpc.RPCFilter{
+		Memcmp: &rpc.RPCFilterMemcmp{Offset: 0, Bytes: solana.Base58(buf[:width])},
+	}, nil
+}
+
+// SetProgramFilters restricts the getProgramAccounts calls for programAddress to accounts matching filters,
+// call it before the client is shared between workers
+func (r *RPCTest) SetProgramFilters(programAddress string, filters ...rpc.RPCFilter) {
+	if r.programFilters == nil {
+		r.programFilters = make(map[string][]rpc.RPCFilter)
+	}
+	r.programFilters[programAddress] = filters
+}
+
+// programAccountsOpts returns the options of a getProgramAccounts call for the program, nil when it has no filters
+func (r *RPCTest) programAccountsOpts(programAddress string) *rpc.GetProgramAccountsOpts {
+	filters := r.programFilters[programAddress]
+	if len(filters) == 0 {
+		return nil
+	}
+	return &rpc.GetProgramAccountsOpts{Filters: filters}
+}
@@ -0,0 +1,29 @@
+package methods
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// RawCall issues method with arbitrary params and reports whether the RPC returned a non-null result
+func (r *RPCTest) RawCall(ctx context.Context, method string, params []interface{}) (bool, error) {
+	var result json.RawMessage
+	err := r.rpc.RPCCallForInto(
+		withRPCMethod(ctx, method),
+		&result,
+		method,
+		params,
+	)
+	if errors.Is(err, rpc.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+
+	return len(result) > 0 && string(result) != "null", nil
+}
@@ -0,0 +1,147 @@
+package methods
+
+import "context"
+
+// Argument kinds of a registered method
+const (
+	ArgsNone    = "none"    // no account arguments
+	ArgsAccount = "account" // one account per request
+	ArgsBatch   = "batch"   // a batch of 5-14 accounts per request
+	ArgsProgram = "program" // one program address per request
+)
+
+// MethodSpec describes an RPC method the tool can benchmark
+type MethodSpec struct {
+	Name        string `json:"name"`
+	Args        string `json:"args"`
+	Suite       bool   `json:"suite"` // run by runall, benchmark and the server
+	Description string `json:"description"`
+
+	// Call sends one request with the client's CallOptions, args already checked against Args
+	Call func(ctx context.Context, r *RPCTest, args ...string) (CallResult, error) `json:"-"`
+}
+
+// Registry lists every supported method, the suite methods first in the order they run
+var Registry = []MethodSpec{
+	{
+		Name: "getAccountInfo", Args: ArgsAccount, Suite: true,
+		Description: "Fetch one account",
+		Call: func(ctx context.Context, r *RPCTest, args ...string) (CallResult, error) {
+			found, slot, err := r.GetAccountInfo(ctx, args[0])
+			return CallResult{Empty: err == nil && !found, ContextSlot: slot}, err
+		},
+	},
+	{
+		Name: "getMultipleAccounts", Args: ArgsBatch, Suite: true,
+		Description: "Fetch a batch of accounts in one request",
+		Call: func(ctx context.Context, r *RPCTest, args ...string) (CallResult, error) {
+			complete, slot, err := r.GetMultipleAccounts(ctx, args...)
+			return CallResult{Partial: err == nil && !complete, ContextSlot: slot}, err
+		},
+	},
+	{
+		Name: "getProgramAccounts", Args: ArgsProgram, Suite: true,
+		Description: "Enumerate the accounts owned by a program",
+		Call: func(ctx context.Context, r *RPCTest, args ...string) (CallResult, error) {
+			if r.callOptions.StreamDecode {
+				counted, err := r.StreamProgramAccounts(ctx, args[0])
+				return CallResult{Counted: counted}, err
+			}
+			if r.callOptions.CountOnly {
+				counted, err := r.CountProgramAccounts(ctx, args[0])
+				return CallResult{Counted: counted}, err
+			}
+			return CallResult{}, r.GetProgramAccounts(ctx, args[0])
+		},
+	},
+	{
+		Name: "getVoteAccounts", Args: ArgsNone,
+		Description: "Fetch the current and delinquent vote accounts",
+		Call: func(ctx context.Context, r *RPCTest, args ...string) (CallResult, error) {
+			return CallResult{}, r.GetVoteAccounts(ctx)
+		},
+	},
+	{
+		Name: "getClusterNodes", Args: ArgsNone,
+		Description: "Fetch the nodes of the cluster",
+		Call: func(ctx context.Context, r *RPCTest, args ...string) (CallResult, error) {
+			return CallResult{}, r.GetClusterNodes(ctx)
+		},
+	},
+	{
+		Name: "getLargestAccounts", Args: ArgsNone,
+		Description: "Fetch the 20 largest accounts by lamports",
+		Call: func(ctx context.Context, r *RPCTest, args ...string) (CallResult, error) {
+			return CallResult{}, r.GetLargestAccounts(ctx)
+		},
+	},
+	{
+		Name: "getSupply", Args: ArgsNone,
+		Description: "Fetch the circulating and total supply",
+		Call: func(ctx context.Context, r *RPCTest, args ...string) (CallResult, error) {
+			return CallResult{}, r.GetSupply(ctx)
+		},
+	},
+	{
+		Name: "getStakeActivation", Args: ArgsAccount,
+		Description: "Fetch the activation state of a stake account",
+		Call: func(ctx context.Context, r *RPCTest, args ...string) (CallResult, error) {
+			return CallResult{}, r.GetStakeActivation(ctx, args[0])
+		},
+	},
+	{
+		Name: "getInflationReward", Args: ArgsBatch,
+		Description: "Fetch the inflation rewards of a batch of addresses",
+		Call: func(ctx context.Context, r *RPCTest, args ...string) (CallResult, error) {
+			return CallResult{}, r.GetInflationReward(ctx, r.callOptions.InflationEpoch, args...)
+		},
+	},
+	{
+		Name: "getFeeForMessage", Args: ArgsNone,
+		Description: "Price a message at a commitment",
+		Call: func(ctx context.Context, r *RPCTest, args ...string) (CallResult, error) {
+			priced, err := r.GetFeeForMessage(ctx, r.callOptions.FeeMessage, r.callOptions.FeeCommitment)
+			return CallResult{Empty: err == nil && !priced}, err
+		},
+	},
+	{
+		Name: "raw", Args: ArgsNone,
+		Description: "Any JSON-RPC method with raw JSON params",
+		Call: func(ctx context.Context, r *RPCTest, args ...string) (CallResult, error) {
+			found, err := r.RawCall(ctx, r.callOptions.RawMethod, r.callOptions.RawParams)
+			return CallResult{Empty: err == nil && !found}, err
+		},
+	},
+}
+
+// LookupMethod returns the registered method called name
+func LookupMethod(name string) (MethodSpec, bool) {
+	for _, spec := range Registry {
+		if spec.Name == name {
+			return spec, true
+		}
+	}
+	return MethodSpec{}, false
+}
+
+// SuiteMethods returns the names of the suite methods in the order they run
+func SuiteMethods() []string {
+	var names []string
+	for _, spec := range Registry {
+		if spec.Suite {
+			names = append(names, spec.Name)
+		}
+	}
+	return names
+}
+
+// MethodsWithArgs returns the set of registered methods taking args
+func MethodsWithArgs(args string) map[string]bool {
+	names := make(map[string]bool)
+	for _, spec := range Registry {
+		if spec.Args == args {
+			names[spec.Name] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,23 @@
+package methods
+
+import (
+	"math/rand"
+	"time"
+)
+
+// MaxRetryBackoff caps the backoff window of a retry however many came before it
+const MaxRetryBackoff = 5 * time.Second
+
+// RetryBackoff returns the pause before a request's retry after retried earlier ones. The window is base
+// doubled per earlier retry, capped at MaxRetryBackoff; with jitter the pause is a random time within it drawn
+// from rng (full jitter), without it the whole window.
+func RetryBackoff(rng *rand.Rand, base time.Duration, retried int, jitter bool) time.Duration {
+	window := min(base<<retried, MaxRetryBackoff)
+	if window <= 0 {
+		return 0
+	}
+	if !jitter {
+		return window
+	}
+	return time.Duration(rng.Int63n(int64(window)) + 1)
+}
@@ -0,0 +1,48 @@
+package methods
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	base := 100 * time.Millisecond
+	rng := rand.New(rand.NewSource(1))
+	for retried := 0; retried <= 8; retried++ {
+		// The window doubles per earlier retry until it reaches the cap
+		window := min(base<<retried, MaxRetryBackoff)
+		if delay := RetryBackoff(rng, base, retried, false); delay != window {
+			t.Fatalf("retry after %d without jitter: delay %s, want the whole %s window", retried, delay, window)
+		}
+
+		var longest time.Duration
+		for i := 0; i < 1000; i++ {
+			delay := RetryBackoff(rng, base, retried, true)
+			if delay <= 0 || delay > window {
+				t.Fatalf("retry after %d: delay %s outside (0, %s]", retried, delay, window)
+			}
+			longest = max(longest, delay)
+		}
+		// Full jitter spreads the delays over the whole window
+		if longest < window/2 {
+			t.Fatalf("retry after %d: longest of 1000 delays is %s, want most of %s", retried, longest, window)
+		}
+	}
+
+	if delay := RetryBackoff(rng, 0, 2, true); delay != 0 {
+		t.Fatalf("zero backoff waited %s", delay)
+	}
+}
+
+func TestRetryBackoffSeeded(t *testing.T) {
+	// The same seed draws the same delays, so a run's retries can be reproduced
+	first, second := rand.New(rand.NewSource(42)), rand.New(rand.NewSource(42))
+	for retried := 0; retried < 5; retried++ {
+		a := RetryBackoff(first, 100*time.Millisecond, retried, true)
+		b := RetryBackoff(second, 100*time.Millisecond, retried, true)
+		if a != b {
+			t.Fatalf("retry after %d: seeded delays %s and %s differ", retried, a, b)
+		}
+	}
+}
@@ -2,16 +2,89 @@ package methods
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gagliardetto/solana-go/rpc"
 )
 
+// PlaceholderAPIKey is the API key of a generated config that was never edited
+const PlaceholderAPIKey = "YOUR_API_KEY_HERE"
+
+// IsPlaceholderAPIKey reports whether apiKey is empty or the unedited placeholder, so not a real key
+func IsPlaceholderAPIKey(apiKey string) bool {
+	apiKey = strings.TrimSpace(apiKey)
+	return apiKey == "" || apiKey == PlaceholderAPIKey
+}
+
 type RPCTest struct {
-	rpc    *rpc.Client
-	rpcUrl string
+	rpc            *rpc.Client
+	http           *http.Client // the client under rpc, for requests that bypass solana-go's decoding
+	rpcUrl         string
+	transport      *trackingTransport
+	programFilters map[string][]rpc.RPCFilter // getProgramAccounts filters per program address
+	callOptions    CallOptions                // options of the calls Dispatch sends
 }
 
 func NewRPCTest(rpcUrl string, apiKey string) *RPCTest {
-	url := fmt.Sprintf("%s?key=%s", rpcUrl, apiKey)
-	return &RPCTest{rpc: rpc.New(url), rpcUrl: url}
+	return NewRPCTestWithOptions(rpcUrl, apiKey, ClientOptions{})
+}
+
+// NewRPCTestWithOptions creates an RPC client with a transport tuned by opts
+func NewRPCTestWithOptions(rpcUrl string, apiKey string, opts ClientOptions) *RPCTest {
+	var socketPath string
+
+	// unix:///path/to/rpc.sock targets a co-located validator over its socket
+	if strings.HasPrefix(rpcUrl, unixScheme) {
+		socketPath = strings.TrimPrefix(rpcUrl, unixScheme)
+		rpcUrl = "http://unix"
+	}
+
+	url := endpointURL(rpcUrl, apiKey)
+	transport := newTrackingTransport(newTransport(socketPath, opts), opts)
+
+	httpClient := newHTTPClient(transport, opts.RequestTimeout)
+
+	return &RPCTest{rpc: newRPCClient(url, httpClient), http: httpClient, rpcUrl: url, transport: transport}
+}
+
+// endpointURL returns rpcUrl with apiKey as its key query parameter, rpcUrl as is when there is no real key
+// so the placeholder is never sent
+func endpointURL(rpcUrl string, apiKey string) string {
+	if IsPlaceholderAPIKey(apiKey) {
+		return rpcUrl
+	}
+	separator := "?"
+	if strings.Contains(rpcUrl, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%skey=%s", rpcUrl, separator, strings.TrimSpace(apiKey))
+}
+
+// NegotiatedProtocol returns the HTTP protocol of the most recent response, e.g. "HTTP/2.0"
+func (r *RPCTest) NegotiatedProtocol() string {
+	if proto, ok := r.transport.protocol.Load().(string); ok {
+		return proto
+	}
+	return "unknown"
+}
+
+// ConnectionStats returns how the client used its connections so far, zeros unless ClientOptions.Connections
+// or ClientOptions.ReconnectOnError was set
+func (r *RPCTest) ConnectionStats() ConnectionStats {
+	return ConnectionStats{
+		Opened:     r.transport.connsOpened.Load(),
+		Waits:      r.transport.connWaits.Load(),
+		WaitTime:   time.Duration(r.transport.connWaitNanos.Load()),
+		Reconnects: r.transport.reconnects.Load(),
+	}
+}
+
+// TransferStats returns the response bytes received so far
+func (r *RPCTest) TransferStats() TransferStats {
+	return TransferStats{
+		WireBytes:    r.transport.wireBytes.Load(),
+		DecodedBytes: r.transport.decodedBytes.Load(),
+	}
 }
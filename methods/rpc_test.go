@@ -0,0 +1,62 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestEndpointURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		apiKey string
+		want   string
+	}{
+		{"no key", "https://rpc.example.com", "", "https://rpc.example.com"},
+		{"placeholder", "https://rpc.example.com", PlaceholderAPIKey, "https://rpc.example.com"},
+		{"padded placeholder", "https://rpc.example.com", " " + PlaceholderAPIKey + "\n", "https://rpc.example.com"},
+		{"blank key", "https://rpc.example.com", "  ", "https://rpc.example.com"},
+		{"key", "https://rpc.example.com", "abc123", "https://rpc.example.com?key=abc123"},
+		{"padded key", "https://rpc.example.com", " abc123 ", "https://rpc.example.com?key=abc123"},
+		{"key after query", "https://rpc.example.com/?region=eu", "abc123", "https://rpc.example.com/?region=eu&key=abc123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := endpointURL(tt.url, tt.apiKey); got != tt.want {
+				t.Fatalf("endpointURL(%q, %q) = %q, want %q", tt.url, tt.apiKey, got, tt.want)
+			}
+			if placeholder := IsPlaceholderAPIKey(tt.apiKey); placeholder != (tt.url == tt.want) {
+				t.Fatalf("IsPlaceholderAPIKey(%q) = %v", tt.apiKey, placeholder)
+			}
+		})
+	}
+}
+
+func TestPlaceholderAPIKeyNotSent(t *testing.T) {
+	var mu sync.Mutex
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		query = r.URL.RawQuery
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":[]}`)
+	}))
+	defer server.Close()
+
+	rpcTest := NewRPCTest(server.URL, PlaceholderAPIKey)
+	if _, err := Dispatch(context.Background(), "getClusterNodes", rpcTest); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if query != "" {
+		t.Fatalf("request was sent with query %q, want none", query)
+	}
+}
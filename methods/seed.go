@@ -1,63 +1,340 @@
 package methods
 
 import (
+	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand"
 	"os"
+	"sort"
+	"time"
 
 	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
 )
 
-// SeedProgramAccounts fetches program accounts and saves their addresses to the specified output file
-func (r *RPCTest) SeedProgramAccounts(programAddress string, outputFile string, limit int) error {
+// Supported values for SampleOptions.Mode
+const (
+	SampleHead   = "head"
+	SampleRandom = "random"
+)
+
+// maxSkippedBlocks bounds how many skipped or unavailable slots SeedAccountsFromRecentBlocks walks past
+const maxSkippedBlocks = 100
+
+// output receives the seeding progress messages, stdout unless SetOutput redirects them
+var output io.Writer = os.Stdout
+
+// SetOutput redirects the seeding progress messages, e.g. to io.Discard to keep stdout machine-readable
+func SetOutput(w io.Writer) {
+	output = w
+}
+
+// fetchProgressInterval is how often a still running getProgramAccounts call reports its elapsed time
+const fetchProgressInterval = 5 * time.Second
+
+// SampleOptions selects which accounts are kept when a seed limit is applied
+type SampleOptions struct {
+	// Mode is head (the first accounts returned by the RPC) or random (a uniform sample)
+	Mode string
+
+	// Seed makes random samples reproducible
+	Seed int64
+}
+
+// ValidateSampleMode checks that mode is one of the supported sampling modes
+func ValidateSampleMode(mode string) error {
+	switch mode {
+	case "", SampleHead, SampleRandom:
+		return nil
+	default:
+		return fmt.Errorf("invalid sample mode %q (expected %s or %s)", mode, SampleHead, SampleRandom)
+	}
+}
+
+// SeedProgramAccounts fetches program accounts and saves the first limit addresses to the specified output file,
+// returning how many accounts the program yielded after the limit, counting those the file already held
+func (r *RPCTest) SeedProgramAccounts(programAddress string, outputFile string, limit int) (int, error) {
+	return r.SeedProgramAccountsSampled(programAddress, outputFile, limit, SampleOptions{Mode: SampleHead})
+}
+
+// SeedProgramAccountsSampled is SeedProgramAccounts with control over which accounts the limit keeps
+func (r *RPCTest) SeedProgramAccountsSampled(programAddress string, outputFile string, limit int, sample SampleOptions) (int, error) {
 	// Parse the program address
 	pubKey, err := solana.PublicKeyFromBase58(programAddress)
 	if err != nil {
-		return fmt.Errorf("invalid program address: %v", err)
+		return 0, fmt.Errorf("invalid program address: %v", err)
 	}
 
-	// Fetch program accounts
-	accounts, err := r.rpc.GetProgramAccounts(
-		context.Background(),
+	// Fetch program accounts, the whole program arrives in a single response
+	seedStart := time.Now()
+	before := r.TransferStats()
+	done := make(chan struct{})
+	go reportFetchProgress(programAddress, seedStart, done)
+
+	accounts, err := r.rpc.GetProgramAccountsWithOpts(
+		withRPCMethod(context.Background(), "getProgramAccounts"),
 		pubKey,
+		r.programAccountsOpts(programAddress),
 	)
+	close(done)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get program accounts after %s: %v", time.Since(seedStart).Round(time.Millisecond), err)
+	}
+
+	after := r.TransferStats()
+	fmt.Fprintf(output, "getProgramAccounts fetch latency: %s, payload %s (%s on the wire)\n",
+		time.Since(seedStart).Round(time.Millisecond),
+		formatPayloadSize(after.DecodedBytes-before.DecodedBytes),
+		formatPayloadSize(after.WireBytes-before.WireBytes))
+
+	addresses := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		addresses = append(addresses, account.Pubkey.String())
+	}
+
+	seeded, err := saveSeededAccounts(addresses, outputFile, limit, sample, "program "+programAddress)
+	if err != nil {
+		return 0, err
+	}
+	fmt.Fprintf(output, "Seeded program %s in %s\n", programAddress, time.Since(seedStart).Round(time.Millisecond))
+	return seeded, nil
+}
+
+// reportFetchProgress prints the elapsed time of a getProgramAccounts call until done is closed
+func reportFetchProgress(programAddress string, start time.Time, done <-chan struct{}) {
+	ticker := time.NewTicker(fetchProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			fmt.Fprintf(output, "Still fetching accounts for program %s, %s elapsed\n", programAddress, time.Since(start).Round(time.Second))
+		}
+	}
+}
+
+// formatPayloadSize formats a byte count in KB or MB
+func formatPayloadSize(bytes int64) string {
+	if bytes >= 1<<20 {
+		return fmt.Sprintf("%.2f MB", float64(bytes)/(1<<20))
+	}
+	return fmt.Sprintf("%.2f KB", float64(bytes)/(1<<10))
+}
+
+// SeedTokenAccounts fetches the SPL token accounts of a wallet and saves their addresses to the specified output file
+func (r *RPCTest) SeedTokenAccounts(owner string, outputFile string, limit int) error {
+	// Parse the owner address
+	pubKey, err := solana.PublicKeyFromBase58(owner)
+	if err != nil {
+		return fmt.Errorf("invalid owner address: %v", err)
+	}
+
+	// Fetch token accounts, only the addresses are needed so skip parsing the data
+	result, err := r.rpc.GetTokenAccountsByOwner(
+		withRPCMethod(context.Background(), "getTokenAccountsByOwner"),
+		pubKey,
+		&rpc.GetTokenAccountsConfig{ProgramId: &solana.TokenProgramID},
+		&rpc.GetTokenAccountsOpts{Encoding: solana.EncodingBase64},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get token accounts: %v", err)
+	}
+
+	addresses := make([]string, 0, len(result.Value))
+	for _, account := range result.Value {
+		addresses = append(addresses, account.Pubkey.String())
+	}
+
+	_, err = saveSeededAccounts(addresses, outputFile, limit, SampleOptions{Mode: SampleHead}, "owner "+owner)
+	return err
+}
+
+// blockAccounts is the part of a getBlock response with transactionDetails "accounts" that seeding reads
+type blockAccounts struct {
+	Transactions []struct {
+		Transaction struct {
+			AccountKeys []struct {
+				Pubkey string `json:"pubkey"`
+			} `json:"accountKeys"`
+		} `json:"transaction"`
+	} `json:"transactions"`
+}
+
+// SeedAccountsFromRecentBlocks fetches the n most recent confirmed blocks and saves the account keys their
+// transactions touched, most touched first, so the limit keeps the hottest accounts
+func (r *RPCTest) SeedAccountsFromRecentBlocks(n int, outputFile string, limit int) error {
+	ctx := context.Background()
+	seedStart := time.Now()
+
+	slot, err := r.rpc.GetSlot(withRPCMethod(ctx, "getSlot"), rpc.CommitmentConfirmed)
+	if err != nil {
+		return fmt.Errorf("failed to get slot: %v", err)
+	}
+
+	// Only the account keys are needed, so skip transaction data and rewards
+	opts := map[string]interface{}{
+		"commitment":                     rpc.CommitmentConfirmed,
+		"encoding":                       "json",
+		"transactionDetails":             "accounts",
+		"rewards":                        false,
+		"maxSupportedTransactionVersion": 0,
+	}
+
+	touches := make(map[string]int)
+	fetched, skipped := 0, 0
+	for ; fetched < n && slot > 0; slot-- {
+		var block *blockAccounts
+		err := r.rpc.RPCCallForInto(withRPCMethod(ctx, "getBlock"), &block, "getBlock", []interface{}{slot, opts})
+
+		// Skipped slots and blocks not yet available come back as RPC errors or null
+		var rpcErr *jsonrpc.RPCError
+		if errors.As(err, &rpcErr) || (err == nil && block == nil) {
+			skipped++
+			if skipped > maxSkippedBlocks {
+				return fmt.Errorf("found only %d of %d blocks after skipping %d slots", fetched, n, skipped-1)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %v", slot, err)
+		}
+
+		for _, tx := range block.Transactions {
+			for _, key := range tx.Transaction.AccountKeys {
+				touches[key.Pubkey]++
+			}
+		}
+		fetched++
+		fmt.Fprintf(output, "Fetched block %d (%d/%d), %d transactions\n", slot, fetched, n, len(block.Transactions))
+	}
+
+	addresses := make([]string, 0, len(touches))
+	for address := range touches {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		if touches[addresses[i]] != touches[addresses[j]] {
+			return touches[addresses[i]] > touches[addresses[j]]
+		}
+		return addresses[i] < addresses[j]
+	})
+
+	source := fmt.Sprintf("%d recent blocks", fetched)
+	if _, err := saveSeededAccounts(addresses, outputFile, limit, SampleOptions{Mode: SampleHead}, source); err != nil {
+		return err
+	}
+	fmt.Fprintf(output, "Seeded %s in %s\n", source, time.Since(seedStart).Round(time.Millisecond))
+	return nil
+}
+
+// saveSeededAccounts appends up to limit addresses to outputFile, skipping addresses the file already contains,
+// and returns how many addresses the limit kept, so a source that yielded none is told apart from a full file
+func saveSeededAccounts(addresses []string, outputFile string, limit int, sample SampleOptions, source string) (int, error) {
+	existing, err := readSeededAccounts(outputFile)
 	if err != nil {
-		return fmt.Errorf("failed to get program accounts: %v", err)
+		return 0, err
 	}
 
 	// Create the output file if it doesn't exist
 	file, err := os.OpenFile(outputFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
-		return fmt.Errorf("failed to create output file: %v", err)
+		return 0, fmt.Errorf("failed to create output file: %v", err)
 	}
 	defer file.Close()
 
+	// A .gz output gets a new gzip member per run, which ReadAccountFile reads back as one list
+	var out io.Writer = file
+	var gz *gzip.Writer
+	if IsGzipPath(outputFile) {
+		gz = gzip.NewWriter(file)
+		out = gz
+	}
+
 	// Apply limit if specified
-	totalAccounts := len(accounts)
+	totalAccounts := len(addresses)
 	if limit > 0 && limit < totalAccounts {
-		accounts = accounts[:limit]
-		fmt.Printf("Limiting to %d accounts out of %d found for program %s\n", limit, totalAccounts, programAddress)
+		if sample.Mode == SampleRandom {
+			addresses = sampleAccounts(addresses, limit, sample.Seed)
+			fmt.Fprintf(output, "Randomly sampling %d accounts out of %d found for %s (seed %d)\n", limit, totalAccounts, source, sample.Seed)
+		} else {
+			addresses = addresses[:limit]
+			fmt.Fprintf(output, "Limiting to %d accounts out of %d found for %s\n", limit, totalAccounts, source)
+		}
 	} else {
-		fmt.Printf("Found %d accounts for program %s\n", totalAccounts, programAddress)
+		fmt.Fprintf(output, "Found %d accounts for %s\n", totalAccounts, source)
 	}
 
-	fmt.Printf("Saving account addresses to %s\n", outputFile)
+	fmt.Fprintf(output, "Saving account addresses to %s\n", outputFile)
+	writeStart := time.Now()
 
 	// Save each account address to the file
-	for i, account := range accounts {
+	saved := 0
+	for i, address := range addresses {
+		if existing[address] {
+			continue
+		}
+		existing[address] = true
+
 		// Write account address to the file
-		if _, err := file.WriteString(account.Pubkey.String() + "\n"); err != nil {
-			return fmt.Errorf("failed to write to output file: %v", err)
+		if _, err := io.WriteString(out, address+"\n"); err != nil {
+			return 0, fmt.Errorf("failed to write to output file: %v", err)
 		}
+		saved++
 
 		if (i+1)%100 == 0 {
-			fmt.Printf("Processed %d/%d accounts\n", i+1, len(accounts))
+			fmt.Fprintf(output, "Processed %d/%d accounts, %s elapsed\n", i+1, len(addresses), time.Since(writeStart).Round(time.Millisecond))
 		}
 	}
 
-	fmt.Printf("Total accounts saved: %d\n", len(accounts))
-	fmt.Printf("Account addresses saved to: %s\n", outputFile)
-	fmt.Printf("Use this file with other commands: --account-file %s\n", outputFile)
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return 0, fmt.Errorf("failed to compress output file: %v", err)
+		}
+	}
 
-	return nil
+	if skipped := len(addresses) - saved; skipped > 0 {
+		fmt.Fprintf(output, "Skipped %d duplicate accounts already in %s\n", skipped, outputFile)
+	}
+	fmt.Fprintf(output, "Total accounts saved: %d\n", saved)
+	fmt.Fprintf(output, "Account addresses saved to: %s\n", outputFile)
+	fmt.Fprintf(output, "Use this file with other commands: --account-file %s\n", outputFile)
+
+	return len(addresses), nil
+}
+
+// sampleAccounts picks n addresses uniformly at random, keeping the RPC's order among the picked ones
+func sampleAccounts(addresses []string, n int, seed int64) []string {
+	rng := rand.New(rand.NewSource(seed))
+	picked := rng.Perm(len(addresses))[:n]
+	sort.Ints(picked)
+
+	sampled := make([]string, 0, n)
+	for _, i := range picked {
+		sampled = append(sampled, addresses[i])
+	}
+	return sampled
+}
+
+// readSeededAccounts returns the addresses already in outputFile, an empty set when it doesn't exist yet
+func readSeededAccounts(outputFile string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+
+	lines, err := ReadAccountFile(outputFile)
+	if os.IsNotExist(err) {
+		return existing, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output file: %v", err)
+	}
+
+	for _, line := range lines {
+		existing[line] = true
+	}
+	return existing, nil
 }
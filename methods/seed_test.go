@@ -0,0 +1,91 @@
+package methods
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// testAddresses returns n distinct placeholder addresses in order
+func testAddresses(n int) []string {
+	addresses := make([]string, n)
+	for i := range addresses {
+		addresses[i] = fmt.Sprintf("account-%04d", i)
+	}
+	return addresses
+}
+
+func TestSampleAccounts(t *testing.T) {
+	addresses := testAddresses(1000)
+
+	sampled := sampleAccounts(addresses, 50, 1)
+	if len(sampled) != 50 {
+		t.Fatalf("sampled %d accounts, want 50", len(sampled))
+	}
+	// Picked accounts keep the RPC's order, so they are a sorted subset without repeats
+	if !slices.IsSorted(sampled) || len(slices.Compact(slices.Clone(sampled))) != len(sampled) {
+		t.Fatalf("sample is not an ordered subset: %v", sampled)
+	}
+	if slices.Equal(sampled, addresses[:50]) {
+		t.Fatalf("random sample is the head of the list")
+	}
+
+	if again := sampleAccounts(addresses, 50, 1); !slices.Equal(sampled, again) {
+		t.Fatalf("the same seed picked different sets:\n%v\n%v", sampled, again)
+	}
+	if other := sampleAccounts(addresses, 50, 2); slices.Equal(sampled, other) {
+		t.Fatalf("seeds 1 and 2 picked the same set: %v", sampled)
+	}
+}
+
+// programAccountsJSON is a getProgramAccounts result listing pubkeys, each holding testAccountJSON
+func programAccountsJSON(pubkeys ...string) string {
+	result := "["
+	for i, pubkey := range pubkeys {
+		if i > 0 {
+			result += ","
+		}
+		result += fmt.Sprintf(`{"pubkey":%q,"account":%s}`, pubkey, testAccountJSON)
+	}
+	return result + "]"
+}
+
+func TestSeedGzipRoundTrip(t *testing.T) {
+	results := []string{
+		programAccountsJSON(testAccountA, testProgram),
+		// The second run repeats an account, which is skipped, and adds a new one in a second gzip member
+		programAccountsJSON(testProgram, testAccountB),
+	}
+	run := 0
+	rpcTest := mockRPC(t, func(method string) string {
+		if method != "getProgramAccounts" {
+			t.Errorf("unexpected %s request", method)
+		}
+		return results[run]
+	})
+
+	path := filepath.Join(t.TempDir(), "accounts.txt.gz")
+	for run = range results {
+		if _, err := rpcTest.SeedProgramAccounts(testProgram, path, 0); err != nil {
+			t.Fatalf("run %d: %v", run+1, err)
+		}
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) < 2 || raw[0] != 0x1f || raw[1] != 0x8b {
+		t.Fatalf("%s is not gzipped", path)
+	}
+
+	accounts, err := ReadAccountFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{testAccountA, testProgram, testAccountB}; !slices.Equal(accounts, want) {
+		t.Fatalf("read back %v, want %v", accounts, want)
+	}
+}
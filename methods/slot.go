@@ -0,0 +1,40 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// minContextSlotNotReachedCode is the JSON-RPC error code of a node asked for state at a slot it has not
+// reached yet
+const minContextSlotNotReachedCode = -32016
+
+// LatestSlot returns the latest slot the endpoint has confirmed
+func (r *RPCTest) LatestSlot(ctx context.Context) (uint64, error) {
+	return r.SlotAt(ctx, rpc.CommitmentConfirmed)
+}
+
+// FinalizedSlot returns the latest finalized slot of the endpoint, the commitment account reads are served at
+func (r *RPCTest) FinalizedSlot(ctx context.Context) (uint64, error) {
+	return r.SlotAt(ctx, rpc.CommitmentFinalized)
+}
+
+// SlotAt returns the latest slot the endpoint has reached at commitment
+func (r *RPCTest) SlotAt(ctx context.Context, commitment rpc.CommitmentType) (uint64, error) {
+	slot, err := r.rpc.GetSlot(withRPCMethod(ctx, "getSlot"), commitment)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get slot: %w", err)
+	}
+	return slot, nil
+}
+
+// minContextSlot returns the minContextSlot of account reads, nil when CallOptions.MinContextSlot is not set
+func (r *RPCTest) minContextSlot() *uint64 {
+	if r.callOptions.MinContextSlot == 0 {
+		return nil
+	}
+	slot := r.callOptions.MinContextSlot
+	return &slot
+}
@@ -0,0 +1,132 @@
+package methods
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+)
+
+// streamedAccount is the part of a getProgramAccounts entry the streaming decoder keeps, so only one
+// pubkey is held at a time however large the response
+type streamedAccount struct {
+	Pubkey string `json:"pubkey"`
+}
+
+// StreamProgramAccounts enumerates the accounts owned by the program like GetProgramAccounts, but decodes
+// the response as it arrives and returns how many accounts it held instead of materializing them, so memory
+// stays flat on programs with millions of accounts. With CallOptions.CountOnly no account data is requested.
+func (r *RPCTest) StreamProgramAccounts(ctx context.Context, programAddress string) (int, error) {
+	if _, err := solana.PublicKeyFromBase58(programAddress); err != nil {
+		return 0, fmt.Errorf("invalid program address: %v", err)
+	}
+
+	config := map[string]interface{}{"encoding": solana.EncodingBase64}
+	if filters := r.programFilters[programAddress]; len(filters) > 0 {
+		config["filters"] = filters
+	}
+	if r.callOptions.CountOnly {
+		config["dataSlice"] = map[string]uint64{"offset": 0, "length": 0}
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getProgramAccounts",
+		"params":  []interface{}{programAddress, config},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(withRPCMethod(ctx, "getProgramAccounts"), http.MethodPost, r.rpcUrl, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stream program accounts: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+		return 0, fmt.Errorf("failed to stream program accounts: HTTP %s", resp.Status)
+	}
+
+	count, err := decodeProgramAccountsStream(json.NewDecoder(resp.Body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to stream program accounts: %w", err)
+	}
+	return count, nil
+}
+
+// decodeProgramAccountsStream walks a JSON-RPC response token by token, counting the entries of its result
+// array one at a time, and returns the endpoint's error as a *jsonrpc.RPCError so it is classified as usual
+func decodeProgramAccountsStream(dec *json.Decoder) (int, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return 0, err
+	}
+
+	count, sawResult := 0, false
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return 0, fmt.Errorf("failed to decode response: %v", err)
+		}
+		switch token {
+		case "result":
+			if err := expectDelim(dec, '['); err != nil {
+				return 0, err
+			}
+			for dec.More() {
+				var account streamedAccount
+				if err := dec.Decode(&account); err != nil {
+					return 0, fmt.Errorf("failed to decode account %d: %v", count, err)
+				}
+				if account.Pubkey == "" {
+					return 0, fmt.Errorf("failed to decode account %d: no pubkey", count)
+				}
+				count++
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return 0, err
+			}
+			sawResult = true
+		case "error":
+			var rpcErr jsonrpc.RPCError
+			if err := dec.Decode(&rpcErr); err != nil {
+				return 0, fmt.Errorf("failed to decode error: %v", err)
+			}
+			return 0, &rpcErr
+		default:
+			// jsonrpc and id
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return 0, fmt.Errorf("failed to decode response: %v", err)
+			}
+		}
+	}
+
+	if !sawResult {
+		return 0, fmt.Errorf("failed to decode response: no result")
+	}
+	return count, nil
+}
+
+// expectDelim reads the next token and fails unless it is delim
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+	if token != delim {
+		return fmt.Errorf("failed to decode response: expected %s, got %v", delim, token)
+	}
+	return nil
+}
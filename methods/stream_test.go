@@ -0,0 +1,75 @@
+package methods
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// benchmarkAccounts is the size of the getProgramAccounts fixture, a mid-sized program
+const benchmarkAccounts = 50000
+
+// programAccountsResponse builds a getProgramAccounts response of n token-account-sized accounts
+func programAccountsResponse(n int) []byte {
+	data := base64.StdEncoding.EncodeToString(make([]byte, 165))
+	var buf bytes.Buffer
+	buf.WriteString(`{"jsonrpc":"2.0","id":1,"result":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		var key solana.PublicKey
+		key[0], key[1], key[2] = byte(i), byte(i>>8), byte(i>>16)
+		fmt.Fprintf(&buf, `{"pubkey":%q,"account":{"data":[%q,"base64"],"executable":false,"lamports":2039280,"owner":%q,"rentEpoch":0}}`,
+			key, data, testProgram)
+	}
+	buf.WriteString(`]}`)
+	return buf.Bytes()
+}
+
+func TestDecodeProgramAccountsStream(t *testing.T) {
+	count, err := decodeProgramAccountsStream(json.NewDecoder(bytes.NewReader(programAccountsResponse(3))))
+	if err != nil || count != 3 {
+		t.Fatalf("counted %d accounts with error %v, want 3", count, err)
+	}
+
+	_, err = decodeProgramAccountsStream(json.NewDecoder(bytes.NewReader([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"Invalid params"}}`))))
+	if code, _, ok := RPCErrorCode(err); !ok || code != -32602 {
+		t.Fatalf("error response gave %v, want the -32602 RPC error", err)
+	}
+}
+
+// BenchmarkProgramAccountsDecode compares counting a large response as it streams with decoding it whole,
+// run with -benchmem to compare the bytes allocated per response
+func BenchmarkProgramAccountsDecode(b *testing.B) {
+	body := programAccountsResponse(benchmarkAccounts)
+
+	b.Run("stream", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+		for i := 0; i < b.N; i++ {
+			count, err := decodeProgramAccountsStream(json.NewDecoder(bytes.NewReader(body)))
+			if err != nil || count != benchmarkAccounts {
+				b.Fatalf("counted %d accounts with error %v", count, err)
+			}
+		}
+	})
+
+	b.Run("full", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+		for i := 0; i < b.N; i++ {
+			var response struct {
+				Result rpc.GetProgramAccountsResult `json:"result"`
+			}
+			if err := json.NewDecoder(bytes.NewReader(body)).Decode(&response); err != nil || len(response.Result) != benchmarkAccounts {
+				b.Fatalf("decoded %d accounts with error %v", len(response.Result), err)
+			}
+		}
+	})
+}
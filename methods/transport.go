@@ -0,0 +1,573 @@
+package methods
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+)
+
+const unixScheme = "unix://"
+
+// Version is reported in the User-Agent of every request
+const Version = "1.0.0"
+
+// DefaultUserAgent is the base User-Agent when ClientOptions.UserAgent is empty
+const DefaultUserAgent = "rpc_test/" + Version
+
+// RequestIDHeader carries a unique ID per request for correlation with server-side logs
+const RequestIDHeader = "X-Request-ID"
+
+// Supported values for ClientOptions.Protocol
+const (
+	ProtocolAuto  = "auto"
+	ProtocolHTTP1 = "http1"
+	ProtocolHTTP2 = "http2"
+)
+
+// Supported values for ClientOptions.Compression
+const (
+	CompressionGzip = "gzip"
+	CompressionNone = "none"
+)
+
+// Match the defaults solana-go uses for its own HTTP client
+const (
+	defaultTimeout             = 5 * time.Minute
+	defaultKeepAlive           = 180 * time.Second
+	defaultMaxIdleConnsPerHost = 9
+)
+
+// connWaitThreshold is how long a request must wait for a pooled connection to count as a stall
+const connWaitThreshold = time.Millisecond
+
+// reconnectDebounce keeps a burst of requests failing on the same reset from emptying the pool over and over
+const reconnectDebounce = 100 * time.Millisecond
+
+// ClientOptions configures the HTTP transport used to reach the target RPC
+type ClientOptions struct {
+	// Protocol pins the HTTP version (http1 or http2), empty or auto lets the transport negotiate
+	Protocol string
+
+	// Compression selects the Accept-Encoding sent to the target, empty defaults to gzip
+	Compression string
+
+	// Proxy routes target traffic through an http, https or socks5 proxy when set
+	Proxy *url.URL
+
+	// UserAgent replaces the base User-Agent, the RPC method is always appended
+	UserAgent string
+
+	// ConnectTimeout bounds establishing a connection, zero keeps the solana-go default
+	ConnectTimeout time.Duration
+
+	// RequestTimeout bounds a whole request including reading the response, zero keeps the solana-go default
+	RequestTimeout time.Duration
+
+	// Connections bounds the transport to this many connections to the target, requests block until one
+	// is free; zero keeps the default pool and leaves connection tracking off
+	Connections int
+
+	// ReconnectOnError closes the pooled idle connections after a connection reset or similar error, so
+	// the next requests dial fresh connections instead of reusing ones the endpoint may have dropped
+	ReconnectOnError bool
+}
+
+// ErrConnectTimeout is wrapped by errors from requests whose connection could not be established in time
+var ErrConnectTimeout = errors.New("connect timeout")
+
+// ErrRateLimited is matched by errors from requests the endpoint answered with HTTP 429
+var ErrRateLimited = errors.New("rate limited (HTTP 429)")
+
+// ErrMalformedData is wrapped by errors from responses that parsed as JSON-RPC but carried account data
+// that does not decode, only checked when CallOptions.ValidateData is set
+var ErrMalformedData = errors.New("malformed account data")
+
+// ErrTooManyNulls is wrapped by getMultipleAccounts errors when fewer accounts came back non-null than
+// CallOptions.RequireNonNullPercent asks for
+var ErrTooManyNulls = errors.New("too many null accounts")
+
+// RateLimitError is returned for an HTTP 429 response, with the wait the endpoint asked for in Retry-After
+type RateLimitError struct {
+	RetryAfter time.Duration // zero when the response had no usable Retry-After
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%v, retry after %s", ErrRateLimited, e.RetryAfter)
+	}
+	return ErrRateLimited.Error()
+}
+
+// Is makes errors.Is(err, ErrRateLimited) match every RateLimitError
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// RetryAfter returns the wait a rate limited endpoint asked for, zero when err isn't a 429 or had no Retry-After
+func RetryAfter(err error) time.Duration {
+	var rateErr *RateLimitError
+	if errors.As(err, &rateErr) {
+		return rateErr.RetryAfter
+	}
+	return 0
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
+
+// Error kinds reported by ClassifyError
+const (
+	ErrorKindConnectTimeout = "connect_timeout"
+	ErrorKindRequestTimeout = "request_timeout"
+	ErrorKindRateLimited    = "rate_limited"
+	ErrorKindRPC            = "rpc"
+	ErrorKindDecode         = "decode"
+	ErrorKindConformance    = "conformance"
+	ErrorKindSlotNotReached = "slot_not_reached"
+	ErrorKindNullAccounts   = "null_accounts"
+	ErrorKindOther          = "other"
+)
+
+// IsTransportErrorKind reports whether an error kind points at the endpoint or network rather than the request
+func IsTransportErrorKind(kind string) bool {
+	switch kind {
+	case ErrorKindRPC, ErrorKindDecode, ErrorKindConformance, ErrorKindSlotNotReached, ErrorKindNullAccounts:
+		return false
+	}
+	return true
+}
+
+// ClassifyError tells an unreachable endpoint apart from a slow one, and both from errors the RPC returned
+func ClassifyError(err error) string {
+	// A JSON-RPC error or a null result means the endpoint answered, the request itself was rejected
+	var rpcErr *jsonrpc.RPCError
+	if errors.As(err, &rpcErr) && rpcErr.Code == minContextSlotNotReachedCode {
+		return ErrorKindSlotNotReached
+	}
+	if errors.As(err, &rpcErr) || errors.Is(err, rpc.ErrNotFound) {
+		return ErrorKindRPC
+	}
+
+	if errors.Is(err, ErrMalformedData) {
+		return ErrorKindDecode
+	}
+	if errors.Is(err, ErrTooManyNulls) {
+		return ErrorKindNullAccounts
+	}
+	if AsConformanceError(err) != nil {
+		return ErrorKindConformance
+	}
+
+	// solana-go does not always keep the error chain intact, so fall back to the message
+	if errors.Is(err, ErrRateLimited) || strings.Contains(err.Error(), ErrRateLimited.Error()) {
+		return ErrorKindRateLimited
+	}
+	if errors.Is(err, ErrConnectTimeout) || strings.Contains(err.Error(), ErrConnectTimeout.Error()) {
+		return ErrorKindConnectTimeout
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) ||
+		strings.Contains(err.Error(), "Client.Timeout exceeded") {
+		return ErrorKindRequestTimeout
+	}
+
+	return ErrorKindOther
+}
+
+// RPCErrorCode returns the code and message of the JSON-RPC error object in err, ok is false when the
+// endpoint did not answer with one
+func RPCErrorCode(err error) (code int, message string, ok bool) {
+	var rpcErr *jsonrpc.RPCError
+	if !errors.As(err, &rpcErr) {
+		return 0, "", false
+	}
+	return rpcErr.Code, rpcErr.Message, true
+}
+
+// TransferStats reports the response bytes received by a client
+type TransferStats struct {
+	WireBytes    int64 // bytes read off the connection, compressed if the server used gzip
+	DecodedBytes int64 // bytes after decompression, as seen by the JSON decoder
+}
+
+// ConnectionStats reports how a client with ClientOptions.Connections used its connections
+type ConnectionStats struct {
+	Opened     int64         // connections dialed to the target
+	Waits      int64         // requests that waited at least connWaitThreshold for a busy connection to free up
+	WaitTime   time.Duration // total time requests spent in those waits
+	Reconnects int64         // times ClientOptions.ReconnectOnError dropped the pooled connections
+}
+
+// ValidateProtocol checks that protocol is one of the supported transport protocols
+func ValidateProtocol(protocol string) error {
+	switch protocol {
+	case "", ProtocolAuto, ProtocolHTTP1, ProtocolHTTP2:
+		return nil
+	default:
+		return fmt.Errorf("invalid protocol %q (expected %s, %s or %s)", protocol, ProtocolAuto, ProtocolHTTP1, ProtocolHTTP2)
+	}
+}
+
+// ResponseStats collects the response bytes of the requests made with a context from WithResponseStats
+type ResponseStats struct {
+	WireBytes    atomic.Int64
+	DecodedBytes atomic.Int64
+}
+
+type responseStatsKey struct{}
+
+type rpcMethodKey struct{}
+
+// withRPCMethod tags a request context with the RPC method it is issuing
+func withRPCMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, rpcMethodKey{}, method)
+}
+
+// WithResponseStats returns a context that attributes response bytes to the returned stats
+func WithResponseStats(ctx context.Context) (context.Context, *ResponseStats) {
+	stats := &ResponseStats{}
+	return context.WithValue(ctx, responseStatsKey{}, stats), stats
+}
+
+// ValidateCompression checks that compression is one of the supported encodings
+func ValidateCompression(compression string) error {
+	switch compression {
+	case "", CompressionGzip, CompressionNone:
+		return nil
+	default:
+		return fmt.Errorf("invalid compression %q (expected %s or %s)", compression, CompressionGzip, CompressionNone)
+	}
+}
+
+// ParseProxyURL validates a proxy URL, accepting the http, https and socks5 schemes
+func ParseProxyURL(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %v", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (expected http, https or socks5)", proxyURL.Scheme)
+	}
+
+	if proxyURL.Hostname() == "" {
+		return nil, fmt.Errorf("proxy URL %q has no host", proxyURL.Redacted())
+	}
+
+	return proxyURL, nil
+}
+
+// CheckProxyReachable dials the proxy once so an unreachable proxy fails before the test starts
+func CheckProxyReachable(proxyURL *url.URL, timeout time.Duration) error {
+	port := proxyURL.Port()
+	if port == "" {
+		switch proxyURL.Scheme {
+		case "http":
+			port = "80"
+		case "https":
+			port = "443"
+		default:
+			port = "1080"
+		}
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(proxyURL.Hostname(), port), timeout)
+	if err != nil {
+		return fmt.Errorf("proxy %s is unreachable: %v", proxyURL.Redacted(), err)
+	}
+	return conn.Close()
+}
+
+// newTransport builds a fresh HTTP transport, dialing socketPath instead of TCP when it is set
+func newTransport(socketPath string, opts ClientOptions) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   defaultTimeout,
+		KeepAlive: defaultKeepAlive,
+	}
+	if opts.ConnectTimeout > 0 {
+		dialer.Timeout = opts.ConnectTimeout
+	}
+
+	dial := func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := dialer.DialContext(ctx, network, address)
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil, fmt.Errorf("%w after %s: %v", ErrConnectTimeout, dialer.Timeout, err)
+		}
+		return conn, err
+	}
+
+	transport := &http.Transport{
+		IdleConnTimeout:     defaultTimeout,
+		MaxConnsPerHost:     defaultMaxIdleConnsPerHost,
+		MaxIdleConnsPerHost: defaultMaxIdleConnsPerHost,
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         dial,
+		ForceAttemptHTTP2:   true,
+		TLSHandshakeTimeout: 10 * time.Second,
+		// gzip is negotiated by trackingTransport so the compressed size stays observable
+		DisableCompression: true,
+	}
+
+	if opts.Connections > 0 {
+		transport.MaxConnsPerHost = opts.Connections
+		transport.MaxIdleConnsPerHost = opts.Connections
+	}
+
+	if opts.Proxy != nil {
+		transport.Proxy = http.ProxyURL(opts.Proxy)
+	}
+
+	if socketPath != "" {
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			// The host in the request URL is ignored, every connection goes to the socket
+			return dial(ctx, "unix", socketPath)
+		}
+	}
+
+	switch opts.Protocol {
+	case ProtocolHTTP1:
+		var protocols http.Protocols
+		protocols.SetHTTP1(true)
+		transport.Protocols = &protocols
+	case ProtocolHTTP2:
+		// Plain http:// targets use h2c with prior knowledge
+		var protocols http.Protocols
+		protocols.SetHTTP2(true)
+		protocols.SetUnencryptedHTTP2(true)
+		transport.Protocols = &protocols
+	}
+
+	return transport
+}
+
+// trackingTransport tags outgoing requests and records details of the responses flowing through the client
+type trackingTransport struct {
+	base         http.RoundTripper
+	compression  string
+	userAgent    string
+	idPrefix     string
+	requestSeq   atomic.Int64
+	protocol     atomic.Value // negotiated protocol of the most recent response
+	wireBytes    atomic.Int64
+	decodedBytes atomic.Int64
+
+	// Connection usage, tracked only when the connections are bounded or reconnects are on
+	traceConns    bool
+	connsOpened   atomic.Int64
+	connWaits     atomic.Int64
+	connWaitNanos atomic.Int64
+
+	reconnectOnError bool
+	reconnects       atomic.Int64
+	lastReconnect    atomic.Int64 // unix nanos of the latest forced reconnect
+}
+
+func newTrackingTransport(base http.RoundTripper, opts ClientOptions) *trackingTransport {
+	userAgent := opts.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	prefix := make([]byte, 6)
+	rand.Read(prefix)
+
+	return &trackingTransport{
+		base:             base,
+		compression:      opts.Compression,
+		userAgent:        userAgent,
+		idPrefix:         hex.EncodeToString(prefix),
+		traceConns:       opts.Connections > 0 || opts.ReconnectOnError,
+		reconnectOnError: opts.ReconnectOnError,
+	}
+}
+
+// traceConnection records whether the request dialed a new connection or stalled waiting for a busy one
+func (t *trackingTransport) traceConnection(req *http.Request) *http.Request {
+	var getConnAt time.Time
+	trace := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			getConnAt = time.Now()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			if !info.Reused {
+				t.connsOpened.Add(1)
+				return
+			}
+			// A reused connection that took a while was busy with another request
+			if wait := time.Since(getConnAt); wait >= connWaitThreshold {
+				t.connWaits.Add(1)
+				t.connWaitNanos.Add(int64(wait))
+			}
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
+
+func (t *trackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the caller's request
+	req = req.Clone(req.Context())
+	if t.traceConns {
+		req = t.traceConnection(req)
+	}
+
+	requestID := fmt.Sprintf("%s-%d", t.idPrefix, t.requestSeq.Add(1))
+	req.Header.Set(RequestIDHeader, requestID)
+
+	userAgent := t.userAgent
+	if method, ok := req.Context().Value(rpcMethodKey{}).(string); ok {
+		userAgent += " method=" + method
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	if t.compression != CompressionNone {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		if t.reconnectOnError && isConnectionError(err) {
+			t.dropIdleConnections()
+		}
+		return nil, err
+	}
+
+	t.protocol.Store(resp.Proto)
+
+	// Surface 429s as their own error so callers can back off instead of counting a generic failure
+	if resp.StatusCode == http.StatusTooManyRequests {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return nil, &RateLimitError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
+
+	wireCounters := []*atomic.Int64{&t.wireBytes}
+	decodedCounters := []*atomic.Int64{&t.decodedBytes}
+	if stats, ok := req.Context().Value(responseStatsKey{}).(*ResponseStats); ok {
+		wireCounters = append(wireCounters, &stats.WireBytes)
+		decodedCounters = append(decodedCounters, &stats.DecodedBytes)
+	}
+
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		resp.Body = &countingBody{Reader: resp.Body, closer: resp.Body, counters: append(wireCounters, decodedCounters...)}
+		return resp, nil
+	}
+
+	// Count the compressed bytes below the gzip reader and the decoded bytes above it
+	wire := &countingBody{Reader: resp.Body, closer: resp.Body, counters: wireCounters}
+	zr, err := gzip.NewReader(wire)
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("invalid gzip response: %v", err)
+	}
+
+	resp.Body = &countingBody{Reader: zr, closer: resp.Body, counters: decodedCounters}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// dropIdleConnections closes the pooled idle connections after a connection error, at most once per
+// reconnectDebounce, the broken connection itself is never reused by net/http
+func (t *trackingTransport) dropIdleConnections() {
+	now := time.Now().UnixNano()
+	last := t.lastReconnect.Load()
+	if now-last < int64(reconnectDebounce) || !t.lastReconnect.CompareAndSwap(last, now) {
+		return
+	}
+	t.CloseIdleConnections()
+	t.reconnects.Add(1)
+}
+
+// isConnectionError reports whether err means the connection itself broke, as opposed to a timeout or
+// an error the endpoint answered with
+func isConnectionError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed) {
+		return true
+	}
+
+	// net/http and its HTTP/2 client report some of these as plain messages
+	msg := err.Error()
+	for _, hint := range []string{"connection reset", "broken pipe", "server closed idle connection", "client connection lost", "GOAWAY"} {
+		if strings.Contains(msg, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// CloseIdleConnections forwards to the underlying transport so http.Client can release connections
+func (t *trackingTransport) CloseIdleConnections() {
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// countingBody adds every byte read to its counters
+type countingBody struct {
+	io.Reader
+	closer   io.Closer
+	counters []*atomic.Int64
+}
+
+func (c *countingBody) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	for _, counter := range c.counters {
+		counter.Add(int64(n))
+	}
+	return n, err
+}
+
+func (c *countingBody) Close() error {
+	return c.closer.Close()
+}
+
+// newHTTPClient wraps a custom transport in an HTTP client with the request timeout
+func newHTTPClient(transport http.RoundTripper, timeout time.Duration) *http.Client {
+	httpClient := &http.Client{
+		Timeout:   defaultTimeout,
+		Transport: transport,
+	}
+	if timeout > 0 {
+		httpClient.Timeout = timeout
+	}
+	return httpClient
+}
+
+// newRPCClient wraps an HTTP client in a solana-go RPC client
+func newRPCClient(url string, httpClient *http.Client) *rpc.Client {
+	return rpc.NewWithCustomRPCClient(jsonrpc.NewClientWithOpts(url, &jsonrpc.RPCClientOpts{
+		HTTPClient: httpClient,
+	}))
+}
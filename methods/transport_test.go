@@ -0,0 +1,109 @@
+package methods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+)
+
+func TestClassifyError(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	dnsFailure := &net.DNSError{Err: "no such host", Name: "rpc.invalid", IsNotFound: true}
+
+	tests := []struct {
+		name      string
+		err       error
+		kind      string
+		transport bool
+	}{
+		// The endpoint or the network failed
+		{"connection refused", fmt.Errorf("rpc call failed: %w", refused), ErrorKindOther, true},
+		{"dns failure", fmt.Errorf("rpc call failed: %w", dnsFailure), ErrorKindOther, true},
+		{"connect timeout", fmt.Errorf("dial: %w", ErrConnectTimeout), ErrorKindConnectTimeout, true},
+		{"request timeout", fmt.Errorf("rpc call failed: %w", context.DeadlineExceeded), ErrorKindRequestTimeout, true},
+		{"rate limited", &RateLimitError{}, ErrorKindRateLimited, true},
+
+		// The endpoint answered and rejected the request
+		{"invalid params", fmt.Errorf("failed: %w", &jsonrpc.RPCError{Code: -32602, Message: "Invalid params"}), ErrorKindRPC, false},
+		{"not found", fmt.Errorf("failed: %w", rpc.ErrNotFound), ErrorKindRPC, false},
+		{"slot not reached", &jsonrpc.RPCError{Code: minContextSlotNotReachedCode, Message: "Minimum context slot has not been reached"}, ErrorKindSlotNotReached, false},
+		{"malformed data", fmt.Errorf("failed: %w", ErrMalformedData), ErrorKindDecode, false},
+		{"too many nulls", fmt.Errorf("%w: 1 of 2 accounts non-null", ErrTooManyNulls), ErrorKindNullAccounts, false},
+		{"conformance", &ConformanceError{Method: "getAccountInfo", Field: "context.slot", Problem: "is null or 0"}, ErrorKindConformance, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind := ClassifyError(tt.err)
+			if kind != tt.kind {
+				t.Fatalf("ClassifyError(%v) = %q, want %q", tt.err, kind, tt.kind)
+			}
+			if got := IsTransportErrorKind(kind); got != tt.transport {
+				t.Fatalf("IsTransportErrorKind(%q) = %v, want %v", kind, got, tt.transport)
+			}
+		})
+	}
+}
+
+func TestClassifyErrorRateLimitMessage(t *testing.T) {
+	// solana-go can flatten the chain into a message, which must still classify
+	err := errors.New("rpc call getAccountInfo() on http://rpc: " + ErrRateLimited.Error())
+	if kind := ClassifyError(err); kind != ErrorKindRateLimited {
+		t.Fatalf("ClassifyError(%v) = %q, want %q", err, kind, ErrorKindRateLimited)
+	}
+}
+
+func TestRPCErrorCodes(t *testing.T) {
+	tests := []struct {
+		code    int
+		message string
+		kind    string
+	}{
+		{-32002, "Transaction simulation failed", ErrorKindRPC},
+		{-32005, "Node is behind by 42 slots", ErrorKindRPC},
+		{-32007, "Slot 1000 was skipped, or missing due to ledger jump to recent snapshot", ErrorKindRPC},
+		{-32009, "Slot 1000 was skipped, or missing in long-term storage", ErrorKindRPC},
+		{minContextSlotNotReachedCode, "Minimum context slot has not been reached", ErrorKindSlotNotReached},
+		{-32601, "Method not found", ErrorKindRPC},
+		{-32602, "Invalid params: invalid type: integer", ErrorKindRPC},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.code), func(t *testing.T) {
+			// Go through the client so the code has to survive solana-go's wrapping
+			rpcTest := mockRPCResponse(t, func(method string) string {
+				return fmt.Sprintf(`"error":{"code":%d,"message":%q}`, tt.code, tt.message)
+			})
+			_, err := Dispatch(context.Background(), "getAccountInfo", rpcTest, testAccountA)
+			if err == nil {
+				t.Fatal("Dispatch succeeded on an error response")
+			}
+
+			code, message, ok := RPCErrorCode(err)
+			if !ok || code != tt.code || message != tt.message {
+				t.Fatalf("RPCErrorCode(%v) = %d, %q, %v, want %d, %q, true", err, code, message, ok, tt.code, tt.message)
+			}
+			if kind := ClassifyError(err); kind != tt.kind {
+				t.Fatalf("ClassifyError(%v) = %q, want %q", err, kind, tt.kind)
+			}
+		})
+	}
+}
+
+func TestRPCErrorCodeWithoutErrorObject(t *testing.T) {
+	for _, err := range []error{
+		fmt.Errorf("failed: %w", rpc.ErrNotFound),
+		fmt.Errorf("rpc call failed: %w", context.DeadlineExceeded),
+		&RateLimitError{},
+	} {
+		if code, _, ok := RPCErrorCode(err); ok {
+			t.Errorf("RPCErrorCode(%v) = %d, want no code", err, code)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package methods
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// ValidateAddress checks that address is a base58 encoded 32 byte public key
+func ValidateAddress(address string) error {
+	if _, err := solana.PublicKeyFromBase58(address); err != nil {
+		return fmt.Errorf("invalid address %q: %v", address, err)
+	}
+	return nil
+}
+
+// ValidateRPCURL checks that rpcURL is an http(s)://host or unix:///path/to/rpc.sock endpoint
+func ValidateRPCURL(rpcURL string) error {
+	if strings.HasPrefix(rpcURL, unixScheme) {
+		if strings.TrimPrefix(rpcURL, unixScheme) == "" {
+			return fmt.Errorf("invalid RPC URL %q: missing socket path", rpcURL)
+		}
+		return nil
+	}
+
+	u, err := url.Parse(rpcURL)
+	if err != nil {
+		return fmt.Errorf("invalid RPC URL %q: %v", rpcURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid RPC URL %q: scheme must be http, https or unix", rpcURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid RPC URL %q: missing host", rpcURL)
+	}
+	return nil
+}
@@ -1,19 +1,27 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
+	"math"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"rpc_test/methods"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bytedance/sonic"
 	"github.com/fasthttp/router"
 	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/pprofhandler"
 )
 
 // ServerConfig represents the server configuration
@@ -24,14 +32,63 @@ type ServerConfig struct {
 
 // MethodConfig represents configuration for a specific method
 type MethodConfig struct {
-	Concurrency int  `json:"concurrency"`
-	Duration    int  `json:"duration"`
-	Limit       int  `json:"limit"`
-	Enabled     bool `json:"enabled"`
+	Concurrency int   `json:"concurrency"`
+	Duration    int   `json:"duration"`
+	Limit       int   `json:"limit"`
+	Enabled     *bool `json:"enabled,omitempty"` // absent means enabled
+
+	// MaxBatchSize caps the accounts per batched request, 1..maxRPCBatchSize, zero uses the RPC limit
+	MaxBatchSize int `json:"max_batch_size,omitempty"`
+
+	// The fields below are pointers so a method can override global_config with zero, absent inherits it
+
+	// Optional pass/fail thresholds, zero disables a threshold
+	MinSuccessRate *float64 `json:"min_success_rate,omitempty"`
+	MaxP95Ms       *float64 `json:"max_p95_ms,omitempty"`
+
+	// TimeoutMs bounds each request, zero keeps the client default
+	TimeoutMs *int `json:"timeout_ms,omitempty"`
+
+	// Retries re-sends a request that failed in transport up to this many times, 0..maxRetries
+	Retries *int `json:"retries,omitempty"`
+
+	// RetryBackoffMs is the first backoff window before a retry, doubled for each further retry up to
+	// methods.MaxRetryBackoff
+	RetryBackoffMs *int `json:"retry_backoff_ms,omitempty"`
+
+	// RetryJitter waits a random time within each backoff window rather than the whole window, absent means on
+	RetryJitter *bool `json:"retry_jitter,omitempty"`
+
+	// SLAThresholdMs reports the percent of successful requests within this latency, zero disables it
+	SLAThresholdMs *float64 `json:"sla_threshold_ms,omitempty"`
 }
 
-type TestRequestSimple struct {
-	Programs []string `json:"programs,omitempty"`
+// maxRetries caps the per-method retries so a dead target can't multiply the load
+const maxRetries = 5
+
+// defaultRetryBackoffMs is the first retry backoff window when a test sets none
+const defaultRetryBackoffMs = 100
+
+// maxRPCBatchSize is the most accounts a Solana RPC accepts in one getMultipleAccounts request
+const maxRPCBatchSize = 100
+
+// enabled reports whether the method runs, true unless set to false
+func (c MethodConfig) enabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// retryJitter reports whether retries back off with full jitter, true unless set to false
+func (c MethodConfig) retryJitter() bool {
+	return c.RetryJitter == nil || *c.RetryJitter
+}
+
+// orZero returns the value of an optional field, zero when it is absent
+func orZero[T any](field *T) T {
+	if field == nil {
+		var zero T
+		return zero
+	}
+	return *field
 }
 
 // TestRequest represents a test request from the API
@@ -41,30 +98,43 @@ type TestRequest struct {
 	TargetRPCURL string                  `json:"target_rpc_url,omitempty"`
 	Methods      map[string]MethodConfig `json:"methods,omitempty"`
 	GlobalConfig MethodConfig            `json:"global_config,omitempty"`
+	Seed         int64                   `json:"seed,omitempty"` // seeds the retry jitter, absent picks one per test
 }
 
 // TestResponse represents the response from a test
 type TestResponse struct {
 	Success   bool          `json:"success"`
+	Passed    bool          `json:"passed"`
 	Message   string        `json:"message"`
 	TestID    string        `json:"test_id,omitempty"`
 	Results   []TestResult  `json:"results,omitempty"`
+	Seed      int64         `json:"seed,omitempty"` // post it back to draw the same retry delays
 	Timestamp time.Time     `json:"timestamp"`
 	Duration  time.Duration `json:"duration"`
 }
 
 // TestResult represents the result of a single method test
 type TestResult struct {
-	MethodName       string  `json:"method_name"`
-	Duration         int64   `json:"duration_micros"`
-	TotalRequests    int64   `json:"total_requests"`
-	SuccessCount     int64   `json:"success_count"`
-	FailureCount     int64   `json:"failure_count"`
-	RequestsPerSec   float64 `json:"requests_per_sec"`
-	SuccessRate      float64 `json:"success_rate"`
-	MinLatencyMicros int64   `json:"min_latency_micros"`
-	MaxLatencyMicros int64   `json:"max_latency_micros"`
-	AvgLatencyMicros int64   `json:"avg_latency_micros"`
+	MethodName        string           `json:"method_name"`
+	Duration          int64            `json:"duration_micros"`
+	TotalRequests     int64            `json:"total_requests"`
+	SuccessCount      int64            `json:"success_count"`
+	FailureCount      int64            `json:"failure_count"`
+	TransportFailures int64            `json:"transport_failures"`
+	RPCFailures       int64            `json:"rpc_failures"`
+	ErrorKinds        map[string]int64 `json:"error_kinds,omitempty"` // failures by kind, e.g. request_timeout or rate_limited
+	Retries           int64            `json:"retries,omitempty"`
+	RequestsPerSec    float64          `json:"requests_per_sec"`
+	SuccessRate       float64          `json:"success_rate"`
+	MinLatencyMicros  int64            `json:"min_latency_micros"`
+	MaxLatencyMicros  int64            `json:"max_latency_micros"`
+	AvgLatencyMicros  int64            `json:"avg_latency_micros"`
+	P95LatencyMicros  int64            `json:"p95_latency_micros"`
+	SLAThresholdMs    float64          `json:"sla_threshold_ms,omitempty"`
+	SLACompliance     *float64         `json:"sla_compliance,omitempty"` // percent of successes within sla_threshold_ms
+	Passed            bool             `json:"passed"`
+	FailReasons       []string         `json:"fail_reasons,omitempty"`
+	Error             string           `json:"error,omitempty"`
 }
 
 // TestConfig represents the configuration for seeding
@@ -76,7 +146,52 @@ type TestConfig struct {
 
 // TestManager manages running tests
 type TestManager struct {
+	mu    sync.Mutex
 	tests map[string]*RunningTest
+	slots chan struct{} // one token per test allowed to run at the same time
+}
+
+// add tracks a test until remove is called
+func (tm *TestManager) add(test *RunningTest) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.tests[test.ID] = test
+}
+
+// remove stops tracking a finished test
+func (tm *TestManager) remove(id string) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	delete(tm.tests, id)
+}
+
+// retryAfter returns the whole seconds until the first running test is expected to end, at least 1
+func (tm *TestManager) retryAfter() int {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	var earliest time.Time
+	for _, test := range tm.tests {
+		if earliest.IsZero() || test.ExpectedEnd.Before(earliest) {
+			earliest = test.ExpectedEnd
+		}
+	}
+	return max(int(math.Ceil(time.Until(earliest).Seconds())), 1)
+}
+
+// tryAcquire reserves a test slot, returning false when the server is at capacity
+func (tm *TestManager) tryAcquire() bool {
+	select {
+	case tm.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release frees a slot reserved by tryAcquire
+func (tm *TestManager) release() {
+	<-tm.slots
 }
 
 // RunningTest represents a test that's currently running
@@ -88,6 +203,9 @@ type RunningTest struct {
 	StartTime time.Time
 	EndTime   time.Time
 	Progress  chan TestProgress
+
+	// ExpectedEnd is when the test should finish: its start plus the durations of the methods it runs
+	ExpectedEnd time.Time
 }
 
 // TestProgress represents progress updates during test execution
@@ -111,12 +229,25 @@ var (
 	testManager *TestManager
 	serverPort  = "8888"
 	serverHost  = "localhost"
+	enablePprof = false
+
+	// Maximum number of tests allowed to run at the same time
+	maxConcurrentTests = 2
+
+	// Longest per-method duration in seconds a test may request
+	maxDuration = 300
 
-	// Global variables for RPC testing
-	rpcURL      = "http://localhost:8080"
-	concurrency = 1
-	duration    = 5
-	limit       = 50
+	// Most concurrent workers a test may request per method
+	maxConcurrency = 100
+
+	// Directory for seeded accounts and per-test temp files
+	dataDir = defaultDataDir()
+
+	// Server defaults for the fields a test request leaves out, never changed while tests run
+	defaultRPCURL      = "http://localhost:8080"
+	defaultConcurrency = 1
+	defaultDuration    = 5
+	defaultLimit       = 50
 )
 
 // JSON response helper
@@ -151,6 +282,27 @@ func corsMiddleware(next fasthttp.RequestHandler) fasthttp.RequestHandler {
 }
 
 func main() {
+	flag.BoolVar(&enablePprof, "enable-pprof", enablePprof, "Expose /debug/pprof for live profiling (keep off in shared environments)")
+	flag.IntVar(&maxConcurrentTests, "max-concurrent-tests", maxConcurrentTests, "Maximum number of tests that may run at once, extra requests get 429")
+	flag.IntVar(&maxDuration, "max-duration", maxDuration, "Longest per-method test duration in seconds a client may request, longer requests get 400")
+	flag.IntVar(&maxConcurrency, "max-concurrency", maxConcurrency, "Most concurrent workers per method a client may request, higher requests get 400")
+	flag.StringVar(&dataDir, "data-dir", dataDir, "Directory for seeded accounts and per-test temp files ($XDG_DATA_HOME/rpc_test by default when XDG_DATA_HOME is set)")
+	flag.Parse()
+
+	if maxConcurrentTests < 1 {
+		log.Fatalf("--max-concurrent-tests must be at least 1")
+	}
+	if maxDuration < 1 {
+		log.Fatalf("--max-duration must be at least 1")
+	}
+	if maxConcurrency < 1 {
+		log.Fatalf("--max-concurrency must be at least 1")
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		log.Fatalf("Failed to create data directory %s (set --data-dir to a writable directory): %v", dataDir, err)
+	}
+	removeLeftoverTempFiles()
+
 	fmt.Println("🚀 Starting RPC Test Server with FastHTTP...")
 	fmt.Printf("📍 Local access: http://localhost:%s\n", serverPort)
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -158,6 +310,7 @@ func main() {
 	// Initialize test manager
 	testManager = &TestManager{
 		tests: make(map[string]*RunningTest),
+		slots: make(chan struct{}, maxConcurrentTests),
 	}
 
 	// Create router
@@ -174,6 +327,9 @@ func main() {
 	fmt.Println("📋 Available endpoints:")
 	fmt.Println("   GET /          - Server information")
 	fmt.Println("   POST /test     - Start a new test")
+	if enablePprof {
+		fmt.Println("   GET /debug/pprof/ - Live profiling (enabled by --enable-pprof)")
+	}
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	if err := fasthttp.ListenAndServe(addr, corsMiddleware(r.Handler)); err != nil {
@@ -186,6 +342,10 @@ func setupRoutes(r *router.Router) {
 	// API routes
 	r.GET("/", handleRoot)
 	r.POST("/test", handleTest)
+
+	if enablePprof {
+		r.GET("/debug/pprof/{profile:*}", pprofhandler.PprofHandler)
+	}
 }
 
 func handleRoot(ctx *fasthttp.RequestCtx) {
@@ -199,7 +359,11 @@ func handleRoot(ctx *fasthttp.RequestCtx) {
 				"GET /":      "Server information",
 				"POST /test": "Start a new test",
 			},
-			"available_methods": []string{"getAccountInfo", "getMultipleAccounts", "getProgramAccounts"},
+			"available_methods":    serverMethodOrder(),
+			"supported_methods":    methods.Registry,
+			"max_concurrent_tests": maxConcurrentTests,
+			"max_duration_s":       maxDuration,
+			"max_concurrency":      maxConcurrency,
 		},
 		Timestamp: time.Now(),
 	}
@@ -208,56 +372,55 @@ func handleRoot(ctx *fasthttp.RequestCtx) {
 }
 
 func handleTest(ctx *fasthttp.RequestCtx) {
-	var reqBody TestRequestSimple
-	var req TestRequest
-	if err := json.Unmarshal(ctx.PostBody(), &reqBody); err != nil {
-		req = TestRequest{
-			RemoteRPCURL: rpcURL,
-			TargetRPCURL: rpcURL,
-			Programs:     []string{"2wT8Yq49kHgDzXuPxZSaeLaH1qbmGXtEyPy64bL7aD3c"},
-			GlobalConfig: MethodConfig{
-				Concurrency: concurrency,
-				Duration:    duration,
-				Limit:       limit,
-				Enabled:     true,
-			},
-		}
-	} else {
-		req = TestRequest{
-			RemoteRPCURL: rpcURL,
-			TargetRPCURL: rpcURL,
-			Programs:     reqBody.Programs,
-			GlobalConfig: MethodConfig{
-				Concurrency: concurrency,
-				Duration:    duration,
-				Limit:       limit,
-				Enabled:     true,
-			},
-		}
-	}
-
-	fmt.Println(req)
-	if req.Methods == nil {
-		req.Methods = make(map[string]MethodConfig)
+	// Reject instead of queueing so a flood of requests can't overload the host or the target
+	if !testManager.tryAcquire() {
+		// A slot frees up when the first running test ends
+		retryAfter := testManager.retryAfter()
+		ctx.Response.Header.Set("Retry-After", strconv.Itoa(retryAfter))
+		writeJSONResponse(ctx, fasthttp.StatusTooManyRequests, APIResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("Server is already running the maximum of %d concurrent tests, retry in %d seconds", maxConcurrentTests, retryAfter),
+			Timestamp: time.Now(),
+		})
+		return
 	}
-
-	// Set defaults for each method if not specified
-	for _, method := range []string{"getAccountInfo", "getMultipleAccounts", "getProgramAccounts"} {
-		req.Methods[method] = MethodConfig{
-			Concurrency: req.GlobalConfig.Concurrency,
-			Duration:    req.GlobalConfig.Duration,
-			Limit:       req.GlobalConfig.Limit,
-			Enabled:     true,
+	defer testManager.release()
+
+	// An empty body runs the default configuration, an unparseable one is rejected rather than guessed at
+	var reqBody TestRequest
+	if body := ctx.PostBody(); len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &reqBody); err != nil {
+			writeJSONResponse(ctx, fasthttp.StatusBadRequest, APIResponse{
+				Success:   false,
+				Message:   fmt.Sprintf("Invalid test request body: %v", err),
+				Timestamp: time.Now(),
+			})
+			return
 		}
 	}
+	if fieldErrors := validateTestRequest(reqBody); len(fieldErrors) > 0 {
+		writeJSONResponse(ctx, fasthttp.StatusBadRequest, APIResponse{
+			Success:   false,
+			Message:   "Invalid test request: " + strings.Join(fieldErrors, "; "),
+			Data:      map[string][]string{"errors": fieldErrors},
+			Timestamp: time.Now(),
+		})
+		return
+	}
+	req := resolveTestRequest(reqBody)
 
 	// Create running test
+	startTime := time.Now()
 	runningTest := &RunningTest{
-		Config:    req,
-		Status:    "running",
-		StartTime: time.Now(),
-		Progress:  make(chan TestProgress, 100),
+		ID:          generateTestID(),
+		Config:      req,
+		Status:      "running",
+		StartTime:   startTime,
+		Progress:    make(chan TestProgress, 100),
+		ExpectedEnd: startTime.Add(plannedDuration(req)),
 	}
+	testManager.add(runningTest)
+	defer testManager.remove(runningTest.ID)
 
 	// change to running test and get data
 	response := runTestAsync(runningTest)
@@ -265,20 +428,6 @@ func handleTest(ctx *fasthttp.RequestCtx) {
 	writeJSONResponse(ctx, fasthttp.StatusOK, response)
 }
 
-// Method executes a specific RPC method
-func Method(name string, rpcTest *methods.RPCTest, account ...string) error {
-	switch name {
-	case "getAccountInfo":
-		return rpcTest.GetAccountInfo(account[0])
-	case "getMultipleAccounts":
-		return rpcTest.GetMultipleAccounts(account...)
-	case "getProgramAccounts":
-		return rpcTest.GetProgramAccounts(account[0])
-	default:
-		return fmt.Errorf("invalid method: %s", name)
-	}
-}
-
 // runTestAsync runs a test synchronously
 func runTestAsync(test *RunningTest) *TestResponse {
 	defer func() {
@@ -288,21 +437,11 @@ func runTestAsync(test *RunningTest) *TestResponse {
 		}
 	}()
 
-	// Run tests for each enabled method
+	// Run tests for each enabled method, everything a method needs comes from test.Config so tests running
+	// at the same time can't see each other's values
 	var allResults []TestResult
 
-	// Store original global values
-	originalRPCURL := rpcURL
-	originalConcurrency := concurrency
-	originalDuration := duration
-	originalLimit := limit
-
-	// Set target RPC URL
-	rpcURL = test.Config.TargetRPCURL
-	var accounts []string
-	var err error
-
-	accounts, err = loadAccountsFromFile("./data/test_accounts.txt", test.Config)
+	accounts, err := loadAccountsFromFile(filepath.Join(dataDir, "test_accounts.txt"), test.ID, test.Config)
 	if err != nil {
 		fmt.Println("Error loading accounts:", err)
 		test.Status = "failed"
@@ -315,34 +454,22 @@ func runTestAsync(test *RunningTest) *TestResponse {
 		return test.Results
 	}
 
-	// Run methods in a specific order
-	methodOrder := []string{"getProgramAccounts", "getAccountInfo", "getMultipleAccounts"}
-
-	for _, methodName := range methodOrder {
+	// Run the suite methods in the server's order
+	for _, methodName := range serverMethodOrder() {
 		methodConfig, exists := test.Config.Methods[methodName]
-		if !exists || !methodConfig.Enabled {
+		if !exists || !methodConfig.enabled() {
 			continue
 		}
 
-		// Set method-specific configuration
-		concurrency = methodConfig.Concurrency
-		duration = methodConfig.Duration
-		limit = methodConfig.Limit
-
 		// Run the method test
 		result := runServerMethod(methodName, &test.Config, accounts)
+		applyThresholds(&result, methodConfig)
 		allResults = append(allResults, result)
 
 		fmt.Printf("Completed %s: %d requests in %v\n",
 			methodName, result.TotalRequests, time.Duration(result.Duration)*time.Microsecond)
 	}
 
-	// Restore original values
-	rpcURL = originalRPCURL
-	concurrency = originalConcurrency
-	duration = originalDuration
-	limit = originalLimit
-
 	// Check if we have any results
 	if len(allResults) == 0 {
 		test.Status = "failed"
@@ -355,31 +482,214 @@ func runTestAsync(test *RunningTest) *TestResponse {
 		return test.Results
 	}
 
+	passed := true
+	for _, result := range allResults {
+		passed = passed && result.Passed
+	}
+
 	test.Status = "completed"
 	test.Results = &TestResponse{
 		Success:   true,
+		Passed:    passed,
 		Message:   "Test completed successfully",
 		TestID:    test.ID,
 		Results:   allResults,
+		Seed:      test.Config.Seed,
 		Timestamp: time.Now(),
 	}
 	return test.Results
 }
 
+// serverMethodOrder returns the suite methods in the order a test runs them: getProgramAccounts first, as the
+// server always has, then the rest in registry order
+func serverMethodOrder() []string {
+	order := []string{"getProgramAccounts"}
+	for _, name := range methods.SuiteMethods() {
+		if name != "getProgramAccounts" {
+			order = append(order, name)
+		}
+	}
+	return order
+}
+
+// plannedDuration returns how long the methods of a test run back to back, seeding not included
+func plannedDuration(req TestRequest) time.Duration {
+	var total time.Duration
+	for _, config := range req.Methods {
+		if config.enabled() {
+			total += time.Duration(config.Duration) * time.Second
+		}
+	}
+	return total
+}
+
+// resolveTestRequest fills every field absent from a posted request with the server's defaults, and disables the
+// suite methods it leaves out when it names any to run
+func resolveTestRequest(reqBody TestRequest) TestRequest {
+	req := TestRequest{
+		RemoteRPCURL: reqBody.RemoteRPCURL,
+		TargetRPCURL: reqBody.TargetRPCURL,
+		Programs:     reqBody.Programs,
+		Methods:      make(map[string]MethodConfig),
+		Seed:         reqBody.Seed,
+	}
+	if req.RemoteRPCURL == "" {
+		req.RemoteRPCURL = defaultRPCURL
+	}
+	if req.TargetRPCURL == "" {
+		req.TargetRPCURL = req.RemoteRPCURL
+	}
+	if len(req.Programs) == 0 {
+		req.Programs = []string{"2wT8Yq49kHgDzXuPxZSaeLaH1qbmGXtEyPy64bL7aD3c"}
+	}
+	if req.Seed == 0 {
+		req.Seed = time.Now().UnixNano()
+	}
+
+	// The server's own defaults may not exceed the caps either
+	retryBackoffMs := defaultRetryBackoffMs
+	req.GlobalConfig = withDefaults(reqBody.GlobalConfig, MethodConfig{
+		Concurrency:    min(defaultConcurrency, maxConcurrency),
+		Duration:       min(defaultDuration, maxDuration),
+		Limit:          defaultLimit,
+		MaxBatchSize:   maxRPCBatchSize,
+		RetryBackoffMs: &retryBackoffMs,
+	})
+
+	// Naming a method that isn't disabled runs only the named methods, otherwise every suite method runs
+	namedOnly := false
+	for _, config := range reqBody.Methods {
+		namedOnly = namedOnly || config.enabled()
+	}
+	disabled := false
+	for _, method := range methods.SuiteMethods() {
+		config, named := reqBody.Methods[method]
+		if namedOnly && !named {
+			config.Enabled = &disabled
+		}
+		req.Methods[method] = withDefaults(config, req.GlobalConfig)
+	}
+	return req
+}
+
+// withDefaults returns config with every absent field taken from defaults
+func withDefaults(config MethodConfig, defaults MethodConfig) MethodConfig {
+	if config.Concurrency == 0 {
+		config.Concurrency = defaults.Concurrency
+	}
+	if config.Duration == 0 {
+		config.Duration = defaults.Duration
+	}
+	if config.Limit == 0 {
+		config.Limit = defaults.Limit
+	}
+	if config.Enabled == nil {
+		config.Enabled = defaults.Enabled
+	}
+	if config.MinSuccessRate == nil {
+		config.MinSuccessRate = defaults.MinSuccessRate
+	}
+	if config.MaxP95Ms == nil {
+		config.MaxP95Ms = defaults.MaxP95Ms
+	}
+	if config.SLAThresholdMs == nil {
+		config.SLAThresholdMs = defaults.SLAThresholdMs
+	}
+	if config.MaxBatchSize == 0 {
+		config.MaxBatchSize = defaults.MaxBatchSize
+	}
+	if config.TimeoutMs == nil {
+		config.TimeoutMs = defaults.TimeoutMs
+	}
+	if config.Retries == nil {
+		config.Retries = defaults.Retries
+	}
+	if config.RetryBackoffMs == nil {
+		config.RetryBackoffMs = defaults.RetryBackoffMs
+	}
+	if config.RetryJitter == nil {
+		config.RetryJitter = defaults.RetryJitter
+	}
+	return config
+}
+
+// validateTestRequest returns one message per invalid field of a test request, empty when it is valid
+func validateTestRequest(reqBody TestRequest) []string {
+	var fieldErrors []string
+	if reqBody.RemoteRPCURL != "" {
+		if err := methods.ValidateRPCURL(reqBody.RemoteRPCURL); err != nil {
+			fieldErrors = append(fieldErrors, fmt.Sprintf("rpc_url: %v", err))
+		}
+	}
+	if reqBody.TargetRPCURL != "" {
+		if err := methods.ValidateRPCURL(reqBody.TargetRPCURL); err != nil {
+			fieldErrors = append(fieldErrors, fmt.Sprintf("target_rpc_url: %v", err))
+		}
+	}
+	for i, program := range reqBody.Programs {
+		if err := methods.ValidateAddress(program); err != nil {
+			fieldErrors = append(fieldErrors, fmt.Sprintf("programs[%d]: %v", i, err))
+		}
+	}
+	fieldErrors = append(fieldErrors, validateMethodConfig("global_config", reqBody.GlobalConfig)...)
+
+	var methodNames []string
+	for method := range reqBody.Methods {
+		methodNames = append(methodNames, method)
+	}
+	sort.Strings(methodNames)
+	for _, method := range methodNames {
+		if spec, ok := methods.LookupMethod(method); !ok || !spec.Suite {
+			fieldErrors = append(fieldErrors, fmt.Sprintf("methods.%s: unknown method (expected one of %s)", method, strings.Join(methods.SuiteMethods(), ", ")))
+			continue
+		}
+		fieldErrors = append(fieldErrors, validateMethodConfig("methods."+method, reqBody.Methods[method])...)
+	}
+	return fieldErrors
+}
+
+// validateMethodConfig returns one message per out of range field of a method or global config
+func validateMethodConfig(field string, config MethodConfig) []string {
+	var fieldErrors []string
+	if size := config.MaxBatchSize; size < 0 || size > maxRPCBatchSize {
+		fieldErrors = append(fieldErrors, fmt.Sprintf("%s.max_batch_size: %d is out of range (expected 1 to %d)", field, size, maxRPCBatchSize))
+	}
+	if workers := config.Concurrency; workers < 0 || workers > maxConcurrency {
+		fieldErrors = append(fieldErrors, fmt.Sprintf("%s.concurrency: %d is out of range (expected at most the server's --max-concurrency of %d)", field, workers, maxConcurrency))
+	}
+	if seconds := config.Duration; seconds < 0 || seconds > maxDuration {
+		fieldErrors = append(fieldErrors, fmt.Sprintf("%s.duration: %ds is out of range (expected at most the server's --max-duration of %ds)", field, seconds, maxDuration))
+	}
+	if config.Limit < 0 {
+		fieldErrors = append(fieldErrors, fmt.Sprintf("%s.limit: %d must not be negative", field, config.Limit))
+	}
+	if timeoutMs := orZero(config.TimeoutMs); timeoutMs < 0 {
+		fieldErrors = append(fieldErrors, fmt.Sprintf("%s.timeout_ms: %d must not be negative", field, timeoutMs))
+	}
+	if retries := orZero(config.Retries); retries < 0 || retries > maxRetries {
+		fieldErrors = append(fieldErrors, fmt.Sprintf("%s.retries: %d is out of range (expected 0 to %d)", field, retries, maxRetries))
+	}
+	if backoffMs := orZero(config.RetryBackoffMs); backoffMs < 0 {
+		fieldErrors = append(fieldErrors, fmt.Sprintf("%s.retry_backoff_ms: %d must not be negative", field, backoffMs))
+	}
+	return fieldErrors
+}
+
 // runServerMethod runs a single method test with the given configuration
 func runServerMethod(methodName string, testConfig *TestRequest, accounts []string) TestResult {
-	if len(accounts) == 0 {
+	// Fail the method instead of crashing the server when filtering left nothing to request, no request
+	// was sent so only the error is reported. Program methods read only the programs, not the accounts
+	spec, _ := methods.LookupMethod(methodName)
+	switch {
+	case (spec.Args == methods.ArgsAccount || spec.Args == methods.ArgsBatch) && len(accounts) == 0:
 		return TestResult{
-			MethodName:       methodName,
-			Duration:         0,
-			TotalRequests:    0,
-			SuccessCount:     0,
-			FailureCount:     1,
-			RequestsPerSec:   0,
-			SuccessRate:      0,
-			MinLatencyMicros: 0,
-			MaxLatencyMicros: 0,
-			AvgLatencyMicros: 0,
+			MethodName: methodName,
+			Error:      "no accounts available",
+		}
+	case spec.Args == methods.ArgsProgram && len(testConfig.Programs) == 0:
+		return TestResult{
+			MethodName: methodName,
+			Error:      "no programs available",
 		}
 	}
 
@@ -391,58 +701,109 @@ func runServerMethod(methodName string, testConfig *TestRequest, accounts []stri
 		accounts = accounts[:methodConfig.Limit]
 	}
 
-	// Create RPC client
-	rpcTest := methods.NewRPCTest(rpcURL)
+	// Create a client of the test's target, timing out each request after the method's timeout_ms
+	rpcTest := methods.NewRPCTestWithOptions(testConfig.TargetRPCURL, "", methods.ClientOptions{
+		RequestTimeout: time.Duration(orZero(methodConfig.TimeoutMs)) * time.Millisecond,
+	})
 
 	startTime := time.Now()
 	endTime := startTime.Add(time.Duration(methodConfig.Duration) * time.Second)
 
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
 	var successCount, failureCount int64
+	var transportFailures, rpcFailures int64
+	var retryCount int64
+	errorKinds := make(map[string]int64)
+	var slaCompliant int64
+	slaThreshold := time.Duration(orZero(methodConfig.SLAThresholdMs) * float64(time.Millisecond))
 	var totalLatency time.Duration
 	var minLatency time.Duration = time.Hour
 	var maxLatency time.Duration
-
-	// Run test synchronously for the duration
-	accountIndex := 0
-	for time.Now().Before(endTime) {
-		// Execute the specified method
-		startReq := time.Now()
-		var err error
-
-		if methodName == "getMultipleAccounts" {
-			numAccounts := rand.Intn(10) + 5
-			if len(accounts) < numAccounts {
-				numAccounts = len(accounts)
+	var latencies []time.Duration // a bounded sample of the successful requests' latencies, for the p95
+
+	// Run methodConfig.Concurrency workers for the duration, each starting at a different account
+	for i := 0; i < max(methodConfig.Concurrency, 1); i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+
+			// Seeded per test and worker, so workers draw different batch sizes and retry delays and a test's can be reproduced
+			rng := rand.New(rand.NewSource(testConfig.Seed + int64(workerID)))
+			accountIndex := workerID
+			for time.Now().Before(endTime) {
+				// Execute the specified method, latency covers every attempt
+				startReq := time.Now()
+				var err error
+				var retried int
+
+				var args []string
+				if spec.Args == methods.ArgsBatch {
+					numAccounts := rng.Intn(10) + 5
+					if numAccounts > methodConfig.MaxBatchSize {
+						numAccounts = methodConfig.MaxBatchSize
+					}
+					if len(accounts) < numAccounts {
+						numAccounts = len(accounts)
+					}
+					for i := 0; i < numAccounts; i++ {
+						idx := (accountIndex + i) % len(accounts)
+						args = append(args, accounts[idx])
+					}
+				} else if spec.Args == methods.ArgsProgram {
+					args = []string{testConfig.Programs[accountIndex%len(testConfig.Programs)]}
+				} else {
+					args = []string{accounts[accountIndex%len(accounts)]}
+				}
+
+				_, err = methods.Dispatch(context.Background(), methodName, rpcTest, args...)
+				for ; err != nil && retried < orZero(methodConfig.Retries) && methods.IsTransportErrorKind(methods.ClassifyError(err)); retried++ {
+					// Back off so a struggling target isn't hit again at once, without running past the test
+					delay := methods.RetryBackoff(rng, time.Duration(orZero(methodConfig.RetryBackoffMs))*time.Millisecond, retried, methodConfig.retryJitter())
+					if time.Now().Add(delay).After(endTime) {
+						break
+					}
+					time.Sleep(delay)
+					_, err = methods.Dispatch(context.Background(), methodName, rpcTest, args...)
+				}
+
+				reqDuration := time.Since(startReq)
+				accountIndex++
+
+				mutex.Lock()
+				retryCount += int64(retried)
+				if err != nil {
+					failureCount++
+					kind := methods.ClassifyError(err)
+					if methods.IsTransportErrorKind(kind) {
+						transportFailures++
+					} else {
+						rpcFailures++
+					}
+					// Log only the first error of each kind, the counts are in the result
+					if errorKinds[kind] == 0 {
+						fmt.Printf("First %s error in %s: %v\n", kind, methodName, err)
+					}
+					errorKinds[kind]++
+				} else {
+					successCount++
+					totalLatency += reqDuration
+					if slaThreshold > 0 && reqDuration <= slaThreshold {
+						slaCompliant++
+					}
+					latencies = retainLatency(latencies, reqDuration, successCount, rng)
+					if reqDuration < minLatency {
+						minLatency = reqDuration
+					}
+					if reqDuration > maxLatency {
+						maxLatency = reqDuration
+					}
+				}
+				mutex.Unlock()
 			}
-			var batchAccounts []string
-			for i := 0; i < numAccounts; i++ {
-				idx := (accountIndex + i) % len(accounts)
-				batchAccounts = append(batchAccounts, accounts[idx])
-			}
-			err = Method(methodName, rpcTest, batchAccounts...)
-		} else if methodName == "getProgramAccounts" {
-			err = Method(methodName, rpcTest, testConfig.Programs...)
-		} else {
-			err = Method(methodName, rpcTest, accounts[accountIndex%len(accounts)])
-		}
-
-		reqDuration := time.Since(startReq)
-		accountIndex++
-
-		if err != nil {
-			failureCount++
-			fmt.Printf("Error in %s: %v\n", methodName, err)
-		} else {
-			successCount++
-			totalLatency += reqDuration
-			if reqDuration < minLatency {
-				minLatency = reqDuration
-			}
-			if reqDuration > maxLatency {
-				maxLatency = reqDuration
-			}
-		}
+		}(i)
 	}
+	wg.Wait()
 
 	// Calculate results
 	totalDuration := time.Since(startTime)
@@ -458,29 +819,98 @@ func runServerMethod(methodName string, testConfig *TestRequest, accounts []stri
 		avgLatency = totalLatency / time.Duration(successCount)
 	}
 
-	return TestResult{
-		MethodName:       methodName,
-		Duration:         totalDuration.Microseconds(),
-		TotalRequests:    totalRequests,
-		SuccessCount:     successCount,
-		FailureCount:     failureCount,
-		RequestsPerSec:   requestsPerSecond,
-		SuccessRate:      successRate,
-		MinLatencyMicros: minLatency.Microseconds(),
-		MaxLatencyMicros: maxLatency.Microseconds(),
-		AvgLatencyMicros: avgLatency.Microseconds(),
+	result := TestResult{
+		MethodName:        methodName,
+		Duration:          totalDuration.Microseconds(),
+		TotalRequests:     totalRequests,
+		SuccessCount:      successCount,
+		FailureCount:      failureCount,
+		TransportFailures: transportFailures,
+		RPCFailures:       rpcFailures,
+		ErrorKinds:        errorKinds,
+		Retries:           retryCount,
+		RequestsPerSec:    requestsPerSecond,
+		SuccessRate:       successRate,
+		MinLatencyMicros:  minLatency.Microseconds(),
+		MaxLatencyMicros:  maxLatency.Microseconds(),
+		AvgLatencyMicros:  avgLatency.Microseconds(),
+		P95LatencyMicros:  percentile(latencies, 95).Microseconds(),
+	}
+	if slaThreshold > 0 && successCount > 0 {
+		compliance := float64(slaCompliant) / float64(successCount) * 100
+		result.SLAThresholdMs, result.SLACompliance = orZero(methodConfig.SLAThresholdMs), &compliance
+	}
+	return result
+}
+
+// maxRetainedLatencies bounds the latencies a test keeps for its p95, so long tests at high concurrency
+// don't grow without limit
+const maxRetainedLatencies = 1 << 20
+
+// retainLatency appends latency until maxRetainedLatencies are kept, then overwrites a random one so the
+// kept latencies stay a uniform sample of all seen successful requests
+func retainLatency(latencies []time.Duration, latency time.Duration, seen int64, rng *rand.Rand) []time.Duration {
+	if len(latencies) < maxRetainedLatencies {
+		return append(latencies, latency)
+	}
+	if i := rng.Int63n(seen); i < int64(len(latencies)) {
+		latencies[i] = latency
+	}
+	return latencies
+}
+
+// percentile returns the p-th percentile of the latencies, 0 when there are none
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	index := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
+
+// applyThresholds marks a result as passed or failed against the method's optional thresholds, always failed
+// when the method reported an error
+func applyThresholds(result *TestResult, config MethodConfig) {
+	result.FailReasons = nil
+
+	// A method that could not run, e.g. without accounts, fails whatever its thresholds
+	if result.Error != "" {
+		result.FailReasons = append(result.FailReasons, result.Error)
+	}
+
+	if minRate := orZero(config.MinSuccessRate); minRate > 0 && result.SuccessRate < minRate {
+		result.FailReasons = append(result.FailReasons,
+			fmt.Sprintf("success rate %.2f%% is below the minimum of %.2f%%", result.SuccessRate, minRate))
+	}
+
+	if maxP95Ms := orZero(config.MaxP95Ms); maxP95Ms > 0 {
+		p95Ms := float64(result.P95LatencyMicros) / 1000
+		if p95Ms > maxP95Ms {
+			result.FailReasons = append(result.FailReasons,
+				fmt.Sprintf("p95 latency %.2fms exceeds the maximum of %.2fms", p95Ms, maxP95Ms))
+		}
 	}
+
+	result.Passed = len(result.FailReasons) == 0
 }
 
-// Load accounts from file
-func loadAccountsFromFile(accountsFile string, testConfig TestRequest) ([]string, error) {
+// Load accounts from file, seeding a temp file private to the test for non-default programs
+func loadAccountsFromFile(accountsFile string, testID string, testConfig TestRequest) ([]string, error) {
 	if testConfig.Programs[0] != "2wT8Yq49kHgDzXuPxZSaeLaH1qbmGXtEyPy64bL7aD3c" {
-		newFile := fmt.Sprintf("./data/test_accounts_%s.txt", testConfig.Programs[0])
+		newFile := filepath.Join(dataDir, fmt.Sprintf(serverAccountsPattern, testID))
+		// Removed on every return, including failed seeding
 		defer os.Remove(newFile)
 		err := seedAccountsFromProgram(newFile, TestConfig{
-			RemoteRPCURL: rpcURL,
+			RemoteRPCURL: testConfig.RemoteRPCURL,
 			Programs:     testConfig.Programs,
-		})
+		}, seedLimit(testConfig))
 		if err != nil {
 			return nil, err
 		}
@@ -503,10 +933,10 @@ func loadAccountsFromFile(accountsFile string, testConfig TestRequest) ([]string
 	return accounts, nil
 }
 
-// seedAccountsFromProgram seeds accounts from a program
-func seedAccountsFromProgram(accountsFile string, config TestConfig) error {
+// seedAccountsFromProgram seeds up to limit accounts from a program
+func seedAccountsFromProgram(accountsFile string, config TestConfig, limit int) error {
 	// Create RPC client for seeding
-	rpcTest := methods.NewRPCTest(config.RemoteRPCURL)
+	rpcTest := methods.NewRPCTest(config.RemoteRPCURL, config.RPCAPIKey)
 
 	// Seed from the first program (or use default)
 	programAddress := "2wT8Yq49kHgDzXuPxZSaeLaH1qbmGXtEyPy64bL7aD3c"
@@ -514,13 +944,50 @@ func seedAccountsFromProgram(accountsFile string, config TestConfig) error {
 		programAddress = config.Programs[0]
 	}
 
-	// Use a reasonable limit for seeding
-	seedLimit := 100
-	if limit > 0 {
-		seedLimit = limit
+	_, err := rpcTest.SeedProgramAccounts(programAddress, accountsFile, limit)
+	return err
+}
+
+// seedLimit returns how many accounts to seed for a test: the largest limit of its enabled methods, so each
+// method gets as many accounts as it asked for, or 100 when none set one
+func seedLimit(testConfig TestRequest) int {
+	limit := 0
+	for _, config := range testConfig.Methods {
+		if config.enabled() {
+			limit = max(limit, config.Limit)
+		}
+	}
+	if limit == 0 {
+		return 100
+	}
+	return limit
+}
+
+// serverAccountsPattern names the temp accounts file of a test, by test ID
+const serverAccountsPattern = "server_accounts_%s.txt"
+
+// removeLeftoverTempFiles deletes temp accounts files left behind by a server that was killed mid-test
+func removeLeftoverTempFiles() {
+	leftovers, err := filepath.Glob(filepath.Join(dataDir, fmt.Sprintf(serverAccountsPattern, "*")))
+	if err != nil {
+		return
 	}
+	for _, file := range leftovers {
+		if err := os.Remove(file); err != nil {
+			fmt.Printf("⚠️  Failed to remove leftover temp file %s: %v\n", file, err)
+		}
+	}
+	if len(leftovers) > 0 {
+		fmt.Printf("🧹 Removed %d leftover temp files from %s\n", len(leftovers), dataDir)
+	}
+}
 
-	return rpcTest.SeedProgramAccounts(programAddress, accountsFile, seedLimit)
+// defaultDataDir is rpc_test under $XDG_DATA_HOME when it is set, ./data otherwise
+func defaultDataDir() string {
+	if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+		return filepath.Join(xdg, "rpc_test")
+	}
+	return "./data"
 }
 
 // generateTestID generates a unique test ID
@@ -0,0 +1,530 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"rpc_test/methods"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Accounts of the test accounts file, valid pubkeys
+var testAccounts = []string{
+	"SysvarRent111111111111111111111111111111111",
+	"SysvarC1ock11111111111111111111111111111111",
+}
+
+// mockTarget is a JSON-RPC endpoint answering getAccountInfo, recording what it was asked
+type mockTarget struct {
+	*httptest.Server
+	requests atomic.Int64
+	inFlight atomic.Int64
+	peak     atomic.Int64
+
+	mu       sync.Mutex
+	accounts map[string]int // requests per account
+}
+
+// newMockTarget starts a target that takes delay to answer each request
+func newMockTarget(t *testing.T, delay time.Duration) *mockTarget {
+	t.Helper()
+
+	m := &mockTarget{accounts: make(map[string]int)}
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := m.inFlight.Add(1)
+		defer m.inFlight.Add(-1)
+		for peak := m.peak.Load(); current > peak && !m.peak.CompareAndSwap(peak, current); peak = m.peak.Load() {
+		}
+
+		var request struct {
+			ID     json.RawMessage   `json:"id"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || len(request.Params) == 0 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var account string
+		json.Unmarshal(request.Params[0], &account)
+
+		m.requests.Add(1)
+		m.mu.Lock()
+		m.accounts[account]++
+		m.mu.Unlock()
+
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"context":{"slot":5},"value":{"data":["","base64"],"executable":false,"lamports":1000,"owner":"11111111111111111111111111111111","rentEpoch":0}}}`, request.ID)
+	}))
+	t.Cleanup(m.Close)
+	return m
+}
+
+// setupServer points the server at a temp data directory holding the test accounts file
+func setupServer(t *testing.T) {
+	t.Helper()
+
+	originalDataDir := dataDir
+	dataDir = t.TempDir()
+	t.Cleanup(func() { dataDir = originalDataDir })
+
+	accountsFile := filepath.Join(dataDir, "test_accounts.txt")
+	if err := os.WriteFile(accountsFile, []byte(testAccounts[0]+"\n"+testAccounts[1]+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testManager = &TestManager{
+		tests: make(map[string]*RunningTest),
+		slots: make(chan struct{}, maxConcurrentTests),
+	}
+}
+
+// ptr returns a pointer to v, for the optional fields of a MethodConfig
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// getAccountInfoRequest is a test request running only getAccountInfo against target
+func getAccountInfoRequest(target string, config MethodConfig) TestRequest {
+	disabled, enabled := false, true
+	config.Enabled = &enabled
+	return TestRequest{
+		TargetRPCURL: target,
+		GlobalConfig: MethodConfig{Enabled: &disabled},
+		Methods:      map[string]MethodConfig{"getAccountInfo": config},
+	}
+}
+
+func TestHandleTestUsesPostedConfig(t *testing.T) {
+	setupServer(t)
+	target := newMockTarget(t, 20*time.Millisecond)
+
+	body, err := json.Marshal(getAccountInfoRequest(target.URL, MethodConfig{Concurrency: 3, Duration: 1, Limit: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ctx fasthttp.RequestCtx
+	ctx.Request.Header.SetMethod(fasthttp.MethodPost)
+	ctx.Request.SetBody(body)
+	handleTest(&ctx)
+
+	if status := ctx.Response.StatusCode(); status != fasthttp.StatusOK {
+		t.Fatalf("status %d: %s", status, ctx.Response.Body())
+	}
+	var response TestResponse
+	if err := json.Unmarshal(ctx.Response.Body(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Results) != 1 || response.Results[0].MethodName != "getAccountInfo" {
+		t.Fatalf("got results %+v, want getAccountInfo alone", response.Results)
+	}
+	result := response.Results[0]
+
+	// target_rpc_url: every request reached the posted target
+	if result.TotalRequests == 0 || result.TotalRequests != target.requests.Load() {
+		t.Fatalf("result counts %d requests, the target received %d", result.TotalRequests, target.requests.Load())
+	}
+	// concurrency: 3 workers each waiting on a slow response
+	if peak := target.peak.Load(); peak != 3 {
+		t.Fatalf("peak in-flight requests %d, want the posted concurrency of 3", peak)
+	}
+	// limit: only the first account was requested
+	if len(target.accounts) != 1 || target.accounts[testAccounts[0]] == 0 {
+		t.Fatalf("requested accounts %v, want only %s with the posted limit of 1", target.accounts, testAccounts[0])
+	}
+	// duration: about the posted second
+	if elapsed := time.Duration(result.Duration) * time.Microsecond; elapsed < time.Second || elapsed > 3*time.Second {
+		t.Fatalf("ran for %s, want about the posted 1s", elapsed)
+	}
+
+	// The server's defaults are untouched
+	if defaultRPCURL != "http://localhost:8080" || defaultConcurrency != 1 || defaultDuration != 5 || defaultLimit != 50 {
+		t.Fatalf("defaults changed: %s %d %d %d", defaultRPCURL, defaultConcurrency, defaultDuration, defaultLimit)
+	}
+}
+
+func TestConcurrentTestsKeepTheirTargets(t *testing.T) {
+	setupServer(t)
+	targets := []*mockTarget{newMockTarget(t, 5*time.Millisecond), newMockTarget(t, 5*time.Millisecond)}
+
+	results := make([]*TestResponse, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target string) {
+			defer wg.Done()
+			config := MethodConfig{Concurrency: 1 + i, Duration: 1, Limit: 1 + i}
+			results[i] = runTestAsync(&RunningTest{
+				ID:     fmt.Sprintf("test_%d", i),
+				Config: resolveTestRequest(getAccountInfoRequest(target, config)),
+			})
+		}(i, target.URL)
+	}
+	wg.Wait()
+
+	for i, target := range targets {
+		if len(results[i].Results) != 1 {
+			t.Fatalf("test %d: got results %+v", i, results[i].Results)
+		}
+		if got, want := results[i].Results[0].TotalRequests, target.requests.Load(); got == 0 || got != want {
+			t.Fatalf("test %d counts %d requests, its target received %d", i, got, want)
+		}
+		if len(target.accounts) != 1+i {
+			t.Fatalf("test %d requested %d accounts, want its limit of %d", i, len(target.accounts), 1+i)
+		}
+	}
+}
+
+func TestNoAccountsFails(t *testing.T) {
+	setupServer(t)
+	if err := os.WriteFile(filepath.Join(dataDir, "test_accounts.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	target := newMockTarget(t, 0)
+
+	response := runTestAsync(&RunningTest{
+		ID:     "test_empty",
+		Config: resolveTestRequest(getAccountInfoRequest(target.URL, MethodConfig{Concurrency: 1, Duration: 1})),
+	})
+
+	if response.Passed {
+		t.Fatalf("a test whose only method had no accounts passed")
+	}
+	if len(response.Results) != 1 {
+		t.Fatalf("got results %+v, want getAccountInfo alone", response.Results)
+	}
+	result := response.Results[0]
+	if result.Passed || result.Error != "no accounts available" || result.TotalRequests != 0 || result.FailureCount != 0 {
+		t.Fatalf("got passed %v, error %q, %d/%d failed, want a failed result with the error and no requests",
+			result.Passed, result.Error, result.FailureCount, result.TotalRequests)
+	}
+	if len(result.FailReasons) != 1 || result.FailReasons[0] != result.Error {
+		t.Fatalf("fail reasons %v, want the error", result.FailReasons)
+	}
+	if target.requests.Load() != 0 {
+		t.Fatalf("the target received %d requests without accounts", target.requests.Load())
+	}
+}
+
+func TestFailuresCountedByKind(t *testing.T) {
+	setupServer(t)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"invalid params"}}`)
+	}))
+	t.Cleanup(target.Close)
+
+	result := runTestAsync(&RunningTest{
+		ID:     "test_failing",
+		Config: resolveTestRequest(getAccountInfoRequest(target.URL, MethodConfig{Concurrency: 2, Duration: 1, Limit: 1})),
+	}).Results[0]
+
+	if result.FailureCount == 0 || result.SuccessCount != 0 {
+		t.Fatalf("got %d successes and %d failures, want only failures", result.SuccessCount, result.FailureCount)
+	}
+	if len(result.ErrorKinds) != 1 || result.ErrorKinds[methods.ErrorKindRPC] != result.FailureCount {
+		t.Fatalf("error kinds %v, want all %d failures as %s", result.ErrorKinds, result.FailureCount, methods.ErrorKindRPC)
+	}
+}
+
+func TestRetryAfterFollowsRunningTests(t *testing.T) {
+	setupServer(t)
+
+	// Fill every slot with tests ending in 12s and 40s
+	for i := 0; i < maxConcurrentTests; i++ {
+		testManager.tryAcquire()
+	}
+	testManager.add(&RunningTest{ID: "late", ExpectedEnd: time.Now().Add(40 * time.Second)})
+	testManager.add(&RunningTest{ID: "early", ExpectedEnd: time.Now().Add(12 * time.Second)})
+
+	var ctx fasthttp.RequestCtx
+	ctx.Request.Header.SetMethod(fasthttp.MethodPost)
+	handleTest(&ctx)
+
+	if status := ctx.Response.StatusCode(); status != fasthttp.StatusTooManyRequests {
+		t.Fatalf("status %d, want 429 at capacity", status)
+	}
+	if retryAfter := string(ctx.Response.Header.Peek("Retry-After")); retryAfter != "12" {
+		t.Fatalf("Retry-After %q, want the 12 seconds until the first test ends", retryAfter)
+	}
+
+	// A test past its expected end still suggests retrying
+	testManager.remove("early")
+	testManager.add(&RunningTest{ID: "overdue", ExpectedEnd: time.Now().Add(-time.Second)})
+	if retryAfter := testManager.retryAfter(); retryAfter != 1 {
+		t.Fatalf("retryAfter %d with an overdue test, want 1", retryAfter)
+	}
+}
+
+func TestApplyThresholds(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  TestResult
+		config  MethodConfig
+		passed  bool
+		reasons int
+	}{
+		{"no thresholds", TestResult{SuccessRate: 50}, MethodConfig{}, true, 0},
+		{"error without thresholds", TestResult{Error: "no accounts available"}, MethodConfig{}, false, 1},
+		{"success rate below minimum", TestResult{SuccessRate: 90}, MethodConfig{MinSuccessRate: ptr(99.0)}, false, 1},
+		{"p95 over maximum", TestResult{SuccessRate: 100, P95LatencyMicros: 300000}, MethodConfig{MaxP95Ms: ptr(250.0)}, false, 1},
+		{"within thresholds", TestResult{SuccessRate: 100, P95LatencyMicros: 100000}, MethodConfig{MinSuccessRate: ptr(99.0), MaxP95Ms: ptr(250.0)}, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.result
+			applyThresholds(&result, tt.config)
+			if result.Passed != tt.passed || len(result.FailReasons) != tt.reasons {
+				t.Fatalf("got passed %v with reasons %v, want passed %v with %d reasons", result.Passed, result.FailReasons, tt.passed, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestRetryBackoffConfig(t *testing.T) {
+	req := resolveTestRequest(TestRequest{Methods: map[string]MethodConfig{"getProgramAccounts": {RetryBackoffMs: ptr(250)}}})
+	if got := orZero(req.Methods["getAccountInfo"].RetryBackoffMs); got != defaultRetryBackoffMs {
+		t.Fatalf("default retry_backoff_ms is %d, want %d", got, defaultRetryBackoffMs)
+	}
+	if got := orZero(req.Methods["getProgramAccounts"].RetryBackoffMs); got != 250 {
+		t.Fatalf("retry_backoff_ms is %d, want the posted 250", got)
+	}
+
+	// Jitter is on unless a method or global_config turns it off
+	off := false
+	req = resolveTestRequest(TestRequest{Methods: map[string]MethodConfig{"getAccountInfo": {RetryJitter: &off}}})
+	if !req.Methods["getProgramAccounts"].retryJitter() || req.Methods["getAccountInfo"].retryJitter() {
+		t.Fatalf("retry_jitter resolved to %v and %v, want on by default and off where posted",
+			req.Methods["getProgramAccounts"].retryJitter(), req.Methods["getAccountInfo"].retryJitter())
+	}
+
+	// A posted seed is kept so a test's retry delays can be reproduced, otherwise each test picks one
+	if req = resolveTestRequest(TestRequest{Seed: 42}); req.Seed != 42 {
+		t.Fatalf("seed resolved to %d, want the posted 42", req.Seed)
+	}
+	if req = resolveTestRequest(TestRequest{}); req.Seed == 0 {
+		t.Fatalf("no seed picked for a request without one")
+	}
+
+	errors := validateTestRequest(TestRequest{GlobalConfig: MethodConfig{RetryBackoffMs: ptr(-1)}})
+	if len(errors) != 1 || !strings.Contains(errors[0], "global_config.retry_backoff_ms") {
+		t.Fatalf("negative retry_backoff_ms gave %v, want one global_config.retry_backoff_ms error", errors)
+	}
+}
+
+func TestHandleTestRejectsUnparseableBody(t *testing.T) {
+	setupServer(t)
+
+	var ctx fasthttp.RequestCtx
+	ctx.Request.Header.SetMethod(fasthttp.MethodPost)
+	ctx.Request.SetBody([]byte(`{"methods": {"getAccountInfo": {"duration": "10"}}}`))
+	handleTest(&ctx)
+
+	if status := ctx.Response.StatusCode(); status != fasthttp.StatusBadRequest {
+		t.Fatalf("status %d, want 400 for a body that doesn't parse", status)
+	}
+	var response APIResponse
+	if err := json.Unmarshal(ctx.Response.Body(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !strings.HasPrefix(response.Message, "Invalid test request body: ") || !strings.Contains(response.Message, "duration") {
+		t.Fatalf("message %q, want the parse error", response.Message)
+	}
+}
+
+func TestZeroOverridesGlobalConfig(t *testing.T) {
+	var reqBody TestRequest
+	body := `{
+		"global_config": {"retries": 3, "retry_backoff_ms": 500, "timeout_ms": 2000, "min_success_rate": 99},
+		"methods": {
+			"getAccountInfo": {"retries": 0, "retry_backoff_ms": 0, "timeout_ms": 0, "min_success_rate": 0},
+			"getProgramAccounts": {}
+		}
+	}`
+	if err := json.Unmarshal([]byte(body), &reqBody); err != nil {
+		t.Fatal(err)
+	}
+	req := resolveTestRequest(reqBody)
+
+	// A posted zero overrides global_config, an absent field inherits it
+	zeroed, inherited := req.Methods["getAccountInfo"], req.Methods["getProgramAccounts"]
+	if orZero(zeroed.Retries) != 0 || orZero(zeroed.RetryBackoffMs) != 0 || orZero(zeroed.TimeoutMs) != 0 || orZero(zeroed.MinSuccessRate) != 0 {
+		t.Fatalf("getAccountInfo resolved to retries %d, backoff %dms, timeout %dms, min success rate %.2f, want the posted zeros",
+			orZero(zeroed.Retries), orZero(zeroed.RetryBackoffMs), orZero(zeroed.TimeoutMs), orZero(zeroed.MinSuccessRate))
+	}
+	if orZero(inherited.Retries) != 3 || orZero(inherited.RetryBackoffMs) != 500 || orZero(inherited.TimeoutMs) != 2000 || orZero(inherited.MinSuccessRate) != 99 {
+		t.Fatalf("getProgramAccounts resolved to retries %d, backoff %dms, timeout %dms, min success rate %.2f, want global_config",
+			orZero(inherited.Retries), orZero(inherited.RetryBackoffMs), orZero(inherited.TimeoutMs), orZero(inherited.MinSuccessRate))
+	}
+}
+
+func TestOnlyNamedMethodsRun(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name    string
+		methods map[string]MethodConfig
+		want    []string
+	}{
+		{"none named", nil, methods.SuiteMethods()},
+		{"some named", map[string]MethodConfig{"getAccountInfo": {}, "getProgramAccounts": {Duration: 2}}, []string{"getAccountInfo", "getProgramAccounts"}},
+		{"only disabled named", map[string]MethodConfig{"getProgramAccounts": {Enabled: &disabled}}, []string{"getAccountInfo", "getMultipleAccounts"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resolveTestRequest(TestRequest{Methods: tt.methods})
+			var running []string
+			for _, method := range methods.SuiteMethods() {
+				if req.Methods[method].enabled() {
+					running = append(running, method)
+				}
+			}
+			sort.Strings(running)
+			want := append([]string(nil), tt.want...)
+			sort.Strings(want)
+			if strings.Join(running, ",") != strings.Join(want, ",") {
+				t.Fatalf("running %v, want %v", running, want)
+			}
+		})
+	}
+}
+
+func TestServerMethodOrder(t *testing.T) {
+	// getProgramAccounts runs first, as it did before the registry, then the rest in registry order
+	want := []string{"getProgramAccounts", "getAccountInfo", "getMultipleAccounts"}
+	if got := serverMethodOrder(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("method order %v, want %v", got, want)
+	}
+}
+
+func TestFailedSeedingLeavesNoTempFiles(t *testing.T) {
+	setupServer(t)
+
+	// The seed endpoint rejects getProgramAccounts
+	seed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID json.RawMessage `json:"id"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32010,"message":"excluded from account secondary indexes"}}`, request.ID)
+	}))
+	t.Cleanup(seed.Close)
+
+	request := getAccountInfoRequest(seed.URL, MethodConfig{Concurrency: 1, Duration: 1})
+	request.RemoteRPCURL = seed.URL
+	request.Programs = []string{"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"}
+	test := &RunningTest{ID: "test_bad_seed", Config: resolveTestRequest(request)}
+	response := runTestAsync(test)
+
+	if response.Success || test.Status != "failed" {
+		t.Fatalf("got success %v with status %q, want a failed test", response.Success, test.Status)
+	}
+	if !strings.Contains(response.Message, "Failed to load accounts") || !strings.Contains(response.Message, "excluded from account secondary indexes") {
+		t.Fatalf("message %q does not report the seeding error", response.Message)
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(dataDir, fmt.Sprintf(serverAccountsPattern, "*"))); len(leftovers) > 0 {
+		t.Fatalf("failed test left temp files behind: %v", leftovers)
+	}
+}
+
+func TestRemoveLeftoverTempFiles(t *testing.T) {
+	setupServer(t)
+	leftover := filepath.Join(dataDir, fmt.Sprintf(serverAccountsPattern, "test_killed"))
+	if err := os.WriteFile(leftover, []byte(testAccounts[0]+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	removeLeftoverTempFiles()
+
+	if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+		t.Fatalf("leftover %s still exists (%v)", leftover, err)
+	}
+	// The shared accounts file is not a temp file
+	if _, err := os.Stat(filepath.Join(dataDir, "test_accounts.txt")); err != nil {
+		t.Fatalf("test_accounts.txt was removed: %v", err)
+	}
+}
+
+func TestSeededTestLeavesNoTempFiles(t *testing.T) {
+	setupServer(t)
+	target := newMockTarget(t, 0)
+
+	// The seed endpoint returns one account of the program
+	seed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID json.RawMessage `json:"id"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":[{"pubkey":"%s","account":{"data":["","base64"],"executable":false,"lamports":1000,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":0}}]}`, request.ID, testAccounts[1])
+	}))
+	t.Cleanup(seed.Close)
+
+	request := getAccountInfoRequest(target.URL, MethodConfig{Concurrency: 1, Duration: 1})
+	request.RemoteRPCURL = seed.URL
+	request.Programs = []string{"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"}
+	response := runTestAsync(&RunningTest{ID: "test_seeded", Config: resolveTestRequest(request)})
+
+	if !response.Success || len(response.Results) != 1 || response.Results[0].SuccessCount == 0 {
+		t.Fatalf("seeded test did not run: %+v", response)
+	}
+	// Only the seeded account was requested
+	if len(target.accounts) != 1 || target.accounts[testAccounts[1]] == 0 {
+		t.Fatalf("requested accounts %v, want only the seeded %s", target.accounts, testAccounts[1])
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(dataDir, fmt.Sprintf(serverAccountsPattern, "*"))); len(leftovers) > 0 {
+		t.Fatalf("finished test left temp files behind: %v", leftovers)
+	}
+}
+
+func TestRetainLatencyIsBounded(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var latencies []time.Duration
+	var seen int64
+	for i := 0; i < maxRetainedLatencies+1000; i++ {
+		seen++
+		latencies = retainLatency(latencies, time.Duration(i), seen, rng)
+	}
+	if len(latencies) != maxRetainedLatencies {
+		t.Fatalf("kept %d latencies, want %d", len(latencies), maxRetainedLatencies)
+	}
+}
+
+func TestProgramMethodsNeedOnlyPrograms(t *testing.T) {
+	target := newMockTarget(t, 0)
+	program := "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+	request := TestRequest{
+		TargetRPCURL: target.URL,
+		Programs:     []string{program},
+		Methods:      map[string]MethodConfig{"getProgramAccounts": {Concurrency: 1, Duration: 1}},
+	}
+
+	// No accounts are needed to enumerate a program
+	if result := runServerMethod("getProgramAccounts", &request, nil); result.Error != "" || result.TotalRequests == 0 {
+		t.Fatalf("got error %q after %d requests, want requests without accounts", result.Error, result.TotalRequests)
+	}
+	target.mu.Lock()
+	asked := target.accounts[program]
+	target.mu.Unlock()
+	if asked == 0 {
+		t.Fatalf("the target was never asked for the program")
+	}
+
+	request.Programs = nil
+	if result := runServerMethod("getProgramAccounts", &request, testAccounts); result.Error != "no programs available" || result.TotalRequests != 0 {
+		t.Fatalf("got error %q after %d requests, want no programs available and no requests", result.Error, result.TotalRequests)
+	}
+}